@@ -23,6 +23,8 @@ const (
 )
 
 type ActiveConnection interface {
+	GetPath() dbus.ObjectPath
+
 	// GetConnection gets connection object of the connection.
 	GetConnection() (Connection, error)
 
@@ -63,6 +65,25 @@ type ActiveConnection interface {
 	GetMaster() (Device, error)
 }
 
+// ActiveConnectionFactory returns a VPNConnection for VPN connections and a
+// plain ActiveConnection otherwise.
+func ActiveConnectionFactory(objectPath dbus.ObjectPath) (ActiveConnection, error) {
+	a, err := NewActiveConnection(objectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	vpn, err := a.GetVPN()
+	if err != nil {
+		return nil, err
+	}
+	if vpn {
+		return NewVPNConnection(objectPath)
+	}
+
+	return a, nil
+}
+
 func NewActiveConnection(objectPath dbus.ObjectPath) (ActiveConnection, error) {
 	var a activeConnection
 	return &a, a.init(NetworkManagerInterface, objectPath)
@@ -72,6 +93,10 @@ type activeConnection struct {
 	dbusBase
 }
 
+func (a *activeConnection) GetPath() dbus.ObjectPath {
+	return a.obj.Path()
+}
+
 func (a *activeConnection) GetConnection() (Connection, error) {
 	path, err := a.getObjectProperty(ActiveConnectionProperyConnection)
 	if err != nil {
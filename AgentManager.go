@@ -0,0 +1,173 @@
+package gonetworkmanager
+
+import (
+	"sync"
+)
+
+const (
+	AgentManagerInterface  = NetworkManagerInterface + ".AgentManager"
+	AgentManagerObjectPath = NetworkManagerObjectPath + "/AgentManager"
+
+	AgentManagerRegister                 = AgentManagerInterface + ".Register"
+	AgentManagerRegisterWithCapabilities = AgentManagerInterface + ".RegisterWithCapabilities"
+	AgentManagerUnregister               = AgentManagerInterface + ".Unregister"
+)
+
+// AgentRegistrationStatus is the state of the registration of an
+// AgentManager.
+type AgentRegistrationStatus struct {
+	// Registered tells whether NetworkManager currently knows the agent.
+	Registered bool
+
+	// Identifier and Capabilities are those of the last registration.
+	Identifier   string
+	Capabilities NmSecretAgentCapabilities
+
+	// KeepRegistered tells whether the agent is registered again when
+	// NetworkManager restarts, see AgentManager.KeepRegistered.
+	KeepRegistered bool
+
+	// Err is the error of the last registration attempt, nil if it
+	// succeeded.
+	Err error
+}
+
+type AgentManager interface {
+
+	// Register is called by secret agents to register their ability to
+	// provide and save network secrets. The identifier identifies the agent,
+	// e.g. "org.example.headless-ui".
+	Register(identifier string) error
+
+	// RegisterWithCapabilities is like Register but indicates agent
+	// capabilities to NetworkManager.
+	RegisterWithCapabilities(identifier string, capabilities NmSecretAgentCapabilities) error
+
+	// KeepRegistered is like RegisterWithCapabilities, but registers again
+	// each time NetworkManager restarts, which forgets all agents, until
+	// Unregister is called.
+	KeepRegistered(identifier string, capabilities NmSecretAgentCapabilities) error
+
+	// Unregister is called by secret agents to notify NetworkManager that
+	// they will no longer handle requests for network secrets.
+	Unregister() error
+
+	// GetRegistrationStatus gets the state of the registration.
+	GetRegistrationStatus() AgentRegistrationStatus
+}
+
+func NewAgentManager() (AgentManager, error) {
+	var a agentManager
+	return &a, a.init(NetworkManagerInterface, AgentManagerObjectPath)
+}
+
+type agentManager struct {
+	dbusBase
+
+	// regMu serializes the automatic registrations with KeepRegistered and
+	// Unregister, so that no registration follows Unregister.
+	regMu sync.Mutex
+
+	mu       sync.Mutex
+	status   AgentRegistrationStatus
+	ownerSub *signalSubscription
+}
+
+func (a *agentManager) Register(identifier string) error {
+	err := a.obj.Call(AgentManagerRegister, 0, identifier).Store()
+	a.setRegistered(identifier, NmSecretAgentCapabilityNone, err)
+	return err
+}
+
+func (a *agentManager) RegisterWithCapabilities(identifier string, capabilities NmSecretAgentCapabilities) error {
+	err := a.obj.Call(AgentManagerRegisterWithCapabilities, 0, identifier, uint32(capabilities)).Store()
+	a.setRegistered(identifier, capabilities, err)
+	return err
+}
+
+func (a *agentManager) setRegistered(identifier string, capabilities NmSecretAgentCapabilities, err error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.status.Registered = err == nil
+	a.status.Identifier = identifier
+	a.status.Capabilities = capabilities
+	a.status.Err = err
+}
+
+func (a *agentManager) KeepRegistered(identifier string, capabilities NmSecretAgentCapabilities) error {
+	a.regMu.Lock()
+	defer a.regMu.Unlock()
+
+	a.mu.Lock()
+	if a.ownerSub == nil {
+		a.ownerSub = a.subscribeNameOwnerChanged(NetworkManagerInterface)
+		go a.watchOwner(a.ownerSub)
+	}
+	a.status.KeepRegistered = true
+	a.mu.Unlock()
+
+	return a.RegisterWithCapabilities(identifier, capabilities)
+}
+
+// watchOwner registers the agent again when NetworkManager takes its bus name
+// after a restart.
+func (a *agentManager) watchOwner(sub *signalSubscription) {
+	for {
+		sig, ok := sub.next()
+		if !ok {
+			return
+		}
+		if len(sig.Body) < 3 {
+			continue
+		}
+		if name, _ := sig.Body[0].(string); name != NetworkManagerInterface {
+			continue
+		}
+		newOwner, _ := sig.Body[2].(string)
+
+		a.regMu.Lock()
+		a.mu.Lock()
+		if a.ownerSub != sub || !a.status.KeepRegistered {
+			a.mu.Unlock()
+			a.regMu.Unlock()
+			return
+		}
+		identifier, capabilities := a.status.Identifier, a.status.Capabilities
+		if newOwner == "" {
+			a.status.Registered = false
+		}
+		a.mu.Unlock()
+
+		if newOwner != "" {
+			a.RegisterWithCapabilities(identifier, capabilities)
+		}
+		a.regMu.Unlock()
+	}
+}
+
+func (a *agentManager) Unregister() error {
+	a.regMu.Lock()
+	defer a.regMu.Unlock()
+
+	a.mu.Lock()
+	if a.ownerSub != nil {
+		a.ownerSub.close()
+		a.ownerSub = nil
+	}
+	a.status.KeepRegistered = false
+	a.mu.Unlock()
+
+	err := a.obj.Call(AgentManagerUnregister, 0).Store()
+	if err == nil {
+		a.mu.Lock()
+		a.status.Registered = false
+		a.mu.Unlock()
+	}
+	return err
+}
+
+func (a *agentManager) GetRegistrationStatus() AgentRegistrationStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.status
+}
@@ -0,0 +1,76 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	BluetoothDeviceInterface = DeviceInterface + ".Bluetooth"
+
+	BluetoothDevicePropertyHwAddress      = BluetoothDeviceInterface + ".HwAddress"
+	BluetoothDevicePropertyName           = BluetoothDeviceInterface + ".Name"
+	BluetoothDevicePropertyBtCapabilities = BluetoothDeviceInterface + ".BtCapabilities"
+)
+
+type BluetoothDevice interface {
+	Device
+
+	// GetHwAddress gets the Bluetooth address of the device.
+	GetHwAddress() (string, error)
+
+	// GetName gets the Bluetooth name of the device.
+	GetName() (string, error)
+
+	// GetBtCapabilities gets the network capabilities of the device: PAN
+	// network access point (NAP) and dial-up networking (DUN).
+	GetBtCapabilities() (NmBluetoothCapabilities, error)
+}
+
+func NewBluetoothDevice(objectPath dbus.ObjectPath) (BluetoothDevice, error) {
+	var d bluetoothDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type bluetoothDevice struct {
+	device
+}
+
+func (d *bluetoothDevice) GetHwAddress() (string, error) {
+	return d.getStringProperty(BluetoothDevicePropertyHwAddress)
+}
+
+func (d *bluetoothDevice) GetName() (string, error) {
+	return d.getStringProperty(BluetoothDevicePropertyName)
+}
+
+func (d *bluetoothDevice) GetBtCapabilities() (NmBluetoothCapabilities, error) {
+	v, err := d.getUint32Property(BluetoothDevicePropertyBtCapabilities)
+	return NmBluetoothCapabilities(v), err
+}
+
+func (d *bluetoothDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["HwAddress"], err = d.GetHwAddress(); err != nil {
+		return nil, err
+	}
+	if m["Name"], err = d.GetName(); err != nil {
+		return nil, err
+	}
+	caps, err := d.GetBtCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	names := []string{}
+	for _, flag := range []NmBluetoothCapabilities{NmBluetoothCapabilitiesDun, NmBluetoothCapabilitiesNap} {
+		if caps&flag != 0 {
+			names = append(names, flag.String())
+		}
+	}
+	m["BtCapabilities"] = names
+	return json.Marshal(m)
+}
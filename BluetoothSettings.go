@@ -0,0 +1,29 @@
+package gonetworkmanager
+
+// NewBluetoothPANConnectionSettings returns a profile tethering to the paired
+// device with the Bluetooth address bdaddr, such as "00:11:22:33:44:55",
+// through its PAN network access point.
+func NewBluetoothPANConnectionSettings(id, bdaddr string) (ConnectionSettings, error) {
+	settings := newConnectionSettings(id, SettingBluetooth)
+	settings[SettingBluetooth] = map[string]interface{}{
+		"bdaddr": bdaddr,
+		"type":   "panu",
+	}
+	return checkedSettings(settings)
+}
+
+// NewBluetoothDUNConnectionSettings returns a profile tethering to the paired
+// phone with the Bluetooth address bdaddr through dial-up networking, using
+// the mobile broadband options gsm.
+func NewBluetoothDUNConnectionSettings(id, bdaddr string, gsm GSMOptions) (ConnectionSettings, error) {
+	settings, err := NewGSMConnectionSettings(id, gsm)
+	if err != nil {
+		return nil, err
+	}
+	settings[SettingConnection]["type"] = SettingBluetooth
+	settings[SettingBluetooth] = map[string]interface{}{
+		"bdaddr": bdaddr,
+		"type":   "dun",
+	}
+	return checkedSettings(settings)
+}
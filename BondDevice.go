@@ -0,0 +1,82 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	BondDeviceInterface = DeviceInterface + ".Bond"
+
+	BondDevicePropertyHwAddress = BondDeviceInterface + ".HwAddress"
+	BondDevicePropertyCarrier   = BondDeviceInterface + ".Carrier"
+	BondDevicePropertySlaves    = BondDeviceInterface + ".Slaves"
+)
+
+type BondDevice interface {
+	Device
+
+	// GetHwAddress gets the hardware (MAC) address of the device.
+	GetHwAddress() (string, error)
+
+	// GetCarrier gets whether the device has carrier.
+	GetCarrier() (bool, error)
+
+	// GetSlaves gets the devices enslaved to the bond.
+	GetSlaves() ([]Device, error)
+
+	// GetMode gets the bonding mode, such as "active-backup", from the
+	// settings applied to the device.
+	GetMode() (string, error)
+}
+
+func NewBondDevice(objectPath dbus.ObjectPath) (BondDevice, error) {
+	var d bondDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type bondDevice struct {
+	device
+}
+
+func (d *bondDevice) GetHwAddress() (string, error) {
+	return d.getStringProperty(BondDevicePropertyHwAddress)
+}
+
+func (d *bondDevice) GetCarrier() (bool, error) {
+	return d.getBoolProperty(BondDevicePropertyCarrier)
+}
+
+func (d *bondDevice) GetSlaves() ([]Device, error) {
+	return d.getSlaves(BondDevicePropertySlaves)
+}
+
+func (d *bondDevice) GetMode() (string, error) {
+	settings, err := d.getAppliedConnection()
+	if err != nil {
+		return "", err
+	}
+	options, _ := settings[SettingBond]["options"].(map[string]string)
+	if mode, ok := options["mode"]; ok {
+		return mode, nil
+	}
+	return "balance-rr", nil
+}
+
+func (d *bondDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["HwAddress"], err = d.GetHwAddress(); err != nil {
+		return nil, err
+	}
+	if m["Carrier"], err = d.GetCarrier(); err != nil {
+		return nil, err
+	}
+	if m["Slaves"], err = d.GetSlaves(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,66 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	BridgeDeviceInterface = DeviceInterface + ".Bridge"
+
+	BridgeDevicePropertyHwAddress = BridgeDeviceInterface + ".HwAddress"
+	BridgeDevicePropertyCarrier   = BridgeDeviceInterface + ".Carrier"
+	BridgeDevicePropertySlaves    = BridgeDeviceInterface + ".Slaves"
+)
+
+type BridgeDevice interface {
+	Device
+
+	// GetHwAddress gets the hardware (MAC) address of the device.
+	GetHwAddress() (string, error)
+
+	// GetCarrier gets whether the device has carrier.
+	GetCarrier() (bool, error)
+
+	// GetSlaves gets the devices attached to the bridge.
+	GetSlaves() ([]Device, error)
+}
+
+func NewBridgeDevice(objectPath dbus.ObjectPath) (BridgeDevice, error) {
+	var d bridgeDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type bridgeDevice struct {
+	device
+}
+
+func (d *bridgeDevice) GetHwAddress() (string, error) {
+	return d.getStringProperty(BridgeDevicePropertyHwAddress)
+}
+
+func (d *bridgeDevice) GetCarrier() (bool, error) {
+	return d.getBoolProperty(BridgeDevicePropertyCarrier)
+}
+
+func (d *bridgeDevice) GetSlaves() ([]Device, error) {
+	return d.getSlaves(BridgeDevicePropertySlaves)
+}
+
+func (d *bridgeDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["HwAddress"], err = d.GetHwAddress(); err != nil {
+		return nil, err
+	}
+	if m["Carrier"], err = d.GetCarrier(); err != nil {
+		return nil, err
+	}
+	if m["Slaves"], err = d.GetSlaves(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
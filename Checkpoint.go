@@ -0,0 +1,146 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	CheckpointInterface = NetworkManagerInterface + ".Checkpoint"
+
+	CheckpointPropertyDevices         = CheckpointInterface + ".Devices"
+	CheckpointPropertyCreated         = CheckpointInterface + ".Created"
+	CheckpointPropertyRollbackTimeout = CheckpointInterface + ".RollbackTimeout"
+)
+
+// Checkpoint is a snapshot of the configuration of devices, taken to roll
+// back to it if a change breaks connectivity.
+type Checkpoint interface {
+	GetPath() dbus.ObjectPath
+
+	// GetDevices gets the devices in the checkpoint.
+	GetDevices() ([]Device, error)
+
+	// GetCreated gets the time the checkpoint was created, in milliseconds
+	// of CLOCK_BOOTTIME.
+	GetCreated() (int64, error)
+
+	// GetRollbackTimeout gets the timeout in seconds after which the
+	// checkpoint is rolled back automatically, 0 if never.
+	GetRollbackTimeout() (uint32, error)
+
+	MarshalJSON() ([]byte, error)
+}
+
+func NewCheckpoint(objectPath dbus.ObjectPath) (Checkpoint, error) {
+	var c checkpoint
+	return &c, c.init(NetworkManagerInterface, objectPath)
+}
+
+type checkpoint struct {
+	dbusBase
+}
+
+func (c *checkpoint) GetPath() dbus.ObjectPath {
+	return c.obj.Path()
+}
+
+func (c *checkpoint) GetDevices() ([]Device, error) {
+	devicePaths, err := c.getSliceObjectProperty(CheckpointPropertyDevices)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]Device, len(devicePaths))
+
+	for i, path := range devicePaths {
+		devices[i], err = DeviceFactory(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return devices, nil
+}
+
+func (c *checkpoint) GetCreated() (int64, error) {
+	return c.getInt64Property(CheckpointPropertyCreated)
+}
+
+func (c *checkpoint) GetRollbackTimeout() (uint32, error) {
+	return c.getUint32Property(CheckpointPropertyRollbackTimeout)
+}
+
+func (c *checkpoint) MarshalJSON() ([]byte, error) {
+	devices, err := c.GetDevices()
+	if err != nil {
+		return nil, err
+	}
+	created, err := c.GetCreated()
+	if err != nil {
+		return nil, err
+	}
+	timeout, err := c.GetRollbackTimeout()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"Devices":         devices,
+		"Created":         created,
+		"RollbackTimeout": timeout,
+	})
+}
+
+// RollbackError is returned by WithCheckpoint when the change was rolled
+// back.
+type RollbackError struct {
+	// Err is the error of the change or of the health check.
+	Err error
+
+	// Results holds the rollback result of each device, by device path.
+	Results map[dbus.ObjectPath]NmRollbackResult
+}
+
+func (e *RollbackError) Error() string {
+	return fmt.Sprintf("rolled back: %v", e.Err)
+}
+
+// WithCheckpoint takes a checkpoint of devices, or of all devices if none are
+// given, and runs change. The change is kept only if both change and
+// healthCheck succeed, otherwise it is rolled back and a *RollbackError is
+// returned. If the process cannot confirm the change within timeout, for
+// example because it lost its connection, NetworkManager rolls it back on its
+// own.
+func WithCheckpoint(devices []Device, timeout time.Duration, change, healthCheck func() error) error {
+	nm, err := NewNetworkManager()
+	if err != nil {
+		return err
+	}
+
+	// The timeout is rounded up to whole seconds, as 0 would disable it.
+	seconds := uint32((timeout + time.Second - 1) / time.Second)
+	if seconds == 0 {
+		seconds = 1
+	}
+	cp, err := nm.CheckpointCreate(devices, seconds, NmCheckpointCreateFlagNone)
+	if err != nil {
+		return err
+	}
+
+	err = change()
+	if err == nil {
+		err = healthCheck()
+	}
+	if err == nil {
+		return nm.CheckpointDestroy(cp)
+	}
+
+	results, rollbackErr := nm.CheckpointRollback(cp)
+	if rollbackErr != nil {
+		return fmt.Errorf("%v; rollback failed: %v", err, rollbackErr)
+	}
+	return &RollbackError{Err: err, Results: results}
+}
@@ -10,6 +10,7 @@ const (
 	ConnectionInterface = SettingsInterface + ".Connection"
 
 	ConnectionGetSettings = ConnectionInterface + ".GetSettings"
+	ConnectionUpdate      = ConnectionInterface + ".Update"
 
 	ConnectionDelete = ConnectionInterface + ".Delete"
 )
@@ -23,11 +24,17 @@ type Connection interface {
 	// GetSettings gets the settings maps describing this network configuration.
 	// This will never include any secrets required for connection to the
 	// network, as those are often protected. Secrets must be requested
-	// separately using the GetSecrets() call.
+	// separately using the GetSecrets() call. Values are decoded with
+	// DecodeSettings.
 	GetSettings() ConnectionSettings
 
+	// Update replaces the settings of the connection with the given ones and
+	// saves them to disk. The settings are encoded as for
+	// Settings.AddConnection.
+	Update(settings ConnectionSettings) error
+
 	// Delete will delete the connection
-	Delete()
+	Delete() error
 
 	MarshalJSON() ([]byte, error)
 }
@@ -49,21 +56,19 @@ func (c *connection) GetSettings() ConnectionSettings {
 	var settings map[string]map[string]dbus.Variant
 	c.call(&settings, ConnectionGetSettings)
 
-	rv := make(ConnectionSettings)
-
-	for k1, v1 := range settings {
-		rv[k1] = make(map[string]interface{})
+	return DecodeSettings(settings)
+}
 
-		for k2, v2 := range v1 {
-			rv[k1][k2] = v2.Value()
-		}
+func (c *connection) Update(settings ConnectionSettings) error {
+	encoded, err := EncodeSettings(settings)
+	if err != nil {
+		return err
 	}
-
-	return rv
+	return c.obj.Call(ConnectionUpdate, 0, encoded).Store()
 }
 
-func (c *connection) Delete() {
-	c.call(c.GetPath(), ConnectionDelete)
+func (c *connection) Delete() error {
+	return c.obj.Call(ConnectionDelete, 0).Store()
 }
 
 func (c *connection) MarshalJSON() ([]byte, error) {
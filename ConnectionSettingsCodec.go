@@ -0,0 +1,733 @@
+package gonetworkmanager
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/godbus/dbus"
+)
+
+// ipDataKeys maps the legacy address and route keys of the ipv4 and ipv6
+// settings to the keys that supersede them. NetworkManager ignores the newer
+// keys when the legacy ones are set, so only one of each pair is sent.
+var ipDataKeys = map[string]string{
+	"addresses": "address-data",
+	"routes":    "route-data",
+}
+
+// EncodeSettings converts settings into the variant maps NetworkManager
+// expects, using the D-Bus signature the schema defines for each known key.
+// The legacy "addresses" and "routes" keys are dropped when "address-data"
+// and "route-data" are set, so that edits to the latter take effect.
+// Besides the exact Go types, convenience values are accepted: Go integers of
+// any size, strings for SSIDs and hardware addresses, IP address strings or
+// net.IP for DNS servers, and "address/prefix" strings, IP4Address or
+// IP4Route values for addresses and routes. The gateway of the first
+// "address/prefix,gateway" string of address-data becomes the gateway key
+// unless that is set. Values that cannot be converted are reported as
+// ValidationErrors.
+func EncodeSettings(settings ConnectionSettings) (map[string]map[string]dbus.Variant, error) {
+	normalized, errs := normalizeSettings(settings)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	rv := make(map[string]map[string]dbus.Variant, len(normalized))
+	for name, group := range normalized {
+		rv[name] = make(map[string]dbus.Variant, len(group))
+		for key, value := range group {
+			if data, ok := ipDataKeys[key]; ok && (name == SettingIP4Config || name == SettingIP6Config) {
+				if _, ok := group[data]; ok {
+					continue
+				}
+			}
+			if kind, ok := settingsSchema[name][key]; ok {
+				rv[name][key] = kind.encode(value)
+			} else if v, ok := value.(dbus.Variant); ok {
+				rv[name][key] = v
+			} else {
+				rv[name][key] = dbus.MakeVariant(value)
+			}
+		}
+	}
+
+	return rv, nil
+}
+
+// DecodeSettings converts the variant maps returned by NetworkManager into
+// ConnectionSettings holding the Go types of the schema. Keys are returned as
+// NetworkManager sent them: depending on its version, addresses and routes
+// are in the legacy "addresses" and "routes" tuples, the newer
+// "address-data" and "route-data" dictionaries, or both.
+func DecodeSettings(raw map[string]map[string]dbus.Variant) ConnectionSettings {
+	rv := make(ConnectionSettings, len(raw))
+
+	for name, group := range raw {
+		rv[name] = make(map[string]interface{}, len(group))
+		for key, variant := range group {
+			value := variant.Value()
+			if kind, ok := settingsSchema[name][key]; ok {
+				if v, err := kind.convert(value); err == nil {
+					value = v
+				}
+			}
+			rv[name][key] = value
+		}
+	}
+
+	return rv
+}
+
+// normalizeSettings returns a copy of settings with the values of all known
+// keys converted to the Go types of the schema.
+func normalizeSettings(settings ConnectionSettings) (ConnectionSettings, ValidationErrors) {
+	var errs ValidationErrors
+	rv := make(ConnectionSettings, len(settings))
+
+	for _, name := range sortedSettingNames(settings) {
+		rv[name] = make(map[string]interface{}, len(settings[name]))
+		for _, key := range sortedKeys(settings[name]) {
+			value := settings[name][key]
+			if kind, ok := settingsSchema[name][key]; ok {
+				v, err := kind.convert(value)
+				if err != nil {
+					errs = append(errs, ValidationError{Path: name + "." + key, Message: err.Error()})
+				} else {
+					value = v
+				}
+			}
+			if schema, ok := nestedSettingsSchema[name+"."+key]; ok {
+				if maps, ok := value.([]map[string]interface{}); ok {
+					errs = append(errs, normalizeNestedSettings(name+"."+key, schema, maps)...)
+				}
+			}
+			rv[name][key] = value
+		}
+
+		// address-data has no gateway, it goes to the gateway key as for
+		// the addresses of keyfiles.
+		if name == SettingIP4Config || name == SettingIP6Config {
+			if _, ok := rv[name]["gateway"]; !ok {
+				entries, _ := parseIPEntries(settings[name]["address-data"])
+				if len(entries) > 0 && entries[0].Gateway != nil {
+					rv[name]["gateway"] = entries[0].Gateway.String()
+				}
+			}
+		}
+	}
+
+	return rv, errs
+}
+
+// normalizeNestedSettings converts the known keys of the elements of an
+// aa{sv} setting key in place, as normalizeSettings does for the keys of a
+// setting.
+func normalizeNestedSettings(path string, schema map[string]settingKind, maps []map[string]interface{}) ValidationErrors {
+	var errs ValidationErrors
+	for i, m := range maps {
+		for _, key := range sortedKeys(m) {
+			kind, ok := schema[key]
+			if !ok {
+				continue
+			}
+			v, err := kind.convert(m[key])
+			if err != nil {
+				errs = append(errs, ValidationError{Path: fmt.Sprintf("%s[%d].%s", path, i, key), Message: err.Error()})
+				continue
+			}
+			m[key] = v
+		}
+	}
+	return errs
+}
+
+// ip6AddressTuple and ip6RouteTuple are the D-Bus structures of the legacy
+// IPv6 "addresses" and "routes" keys.
+type ip6AddressTuple struct {
+	Address []byte
+	Prefix  uint32
+	Gateway []byte
+}
+
+type ip6RouteTuple struct {
+	Route   []byte
+	Prefix  uint32
+	NextHop []byte
+	Metric  uint32
+}
+
+// encode wraps a value previously returned by convert into a variant with the
+// signature of the kind.
+func (k settingKind) encode(v interface{}) dbus.Variant {
+	switch k {
+	case kindIP6Addresses:
+		tuples := v.([][]interface{})
+		rv := make([]ip6AddressTuple, len(tuples))
+		for i, t := range tuples {
+			rv[i] = ip6AddressTuple{t[0].([]byte), t[1].(uint32), t[2].([]byte)}
+		}
+		return dbus.MakeVariant(rv)
+	case kindIP6Routes:
+		tuples := v.([][]interface{})
+		rv := make([]ip6RouteTuple, len(tuples))
+		for i, t := range tuples {
+			rv[i] = ip6RouteTuple{t[0].([]byte), t[1].(uint32), t[2].([]byte), t[3].(uint32)}
+		}
+		return dbus.MakeVariant(rv)
+	case kindAddressData, kindRouteData, kindMaps:
+		maps := v.([]map[string]interface{})
+		rv := make([]map[string]dbus.Variant, len(maps))
+		for i, m := range maps {
+			rv[i] = make(map[string]dbus.Variant, len(m))
+			for key, value := range m {
+				if variant, ok := value.(dbus.Variant); ok {
+					rv[i][key] = variant
+				} else {
+					rv[i][key] = dbus.MakeVariant(value)
+				}
+			}
+		}
+		return dbus.MakeVariant(rv)
+	}
+	return dbus.MakeVariant(v)
+}
+
+// convert converts v to the Go type of the kind.
+func (k settingKind) convert(v interface{}) (interface{}, error) {
+	if variant, ok := v.(dbus.Variant); ok {
+		v = variant.Value()
+	}
+
+	switch k {
+	case kindString:
+		switch t := v.(type) {
+		case string:
+			return t, nil
+		case fmt.Stringer:
+			return t.String(), nil
+		}
+	case kindBool:
+		switch t := v.(type) {
+		case bool:
+			return t, nil
+		case string:
+			return strconv.ParseBool(t)
+		}
+	case kindInt32:
+		if n, ok := toInt64(v); ok {
+			if n < math.MinInt32 || n > math.MaxInt32 {
+				return nil, fmt.Errorf("%d out of range for int32", n)
+			}
+			return int32(n), nil
+		}
+	case kindUint32:
+		if n, ok := toInt64(v); ok {
+			if n < 0 || n > math.MaxUint32 {
+				return nil, fmt.Errorf("%d out of range for uint32", n)
+			}
+			return uint32(n), nil
+		}
+	case kindInt64:
+		if n, ok := toInt64(v); ok {
+			return n, nil
+		}
+	case kindUint64:
+		if n, ok := v.(uint64); ok {
+			return n, nil
+		}
+		if n, ok := toInt64(v); ok {
+			if n < 0 {
+				return nil, fmt.Errorf("%d out of range for uint64", n)
+			}
+			return uint64(n), nil
+		}
+	case kindBytes:
+		switch t := v.(type) {
+		case []byte:
+			return t, nil
+		case string:
+			return []byte(t), nil
+		}
+	case kindMAC:
+		switch t := v.(type) {
+		case []byte:
+			return t, nil
+		case net.HardwareAddr:
+			return []byte(t), nil
+		case string:
+			mac, err := net.ParseMAC(t)
+			if err != nil {
+				return nil, err
+			}
+			return []byte(mac), nil
+		}
+	case kindStrings:
+		switch t := v.(type) {
+		case []string:
+			return t, nil
+		case string:
+			return []string{t}, nil
+		case []interface{}:
+			rv := make([]string, len(t))
+			for i, e := range t {
+				s, ok := e.(string)
+				if !ok {
+					return nil, fmt.Errorf("cannot use %T as string", e)
+				}
+				rv[i] = s
+			}
+			return rv, nil
+		}
+	case kindUint32s:
+		switch t := v.(type) {
+		case []uint32:
+			return t, nil
+		case []int, []interface{}:
+			var rv []uint32
+			for _, e := range toInterfaces(t) {
+				n, err := kindUint32.convert(e)
+				if err != nil {
+					return nil, err
+				}
+				rv = append(rv, n.(uint32))
+			}
+			return rv, nil
+		}
+	case kindIP4s:
+		if t, ok := v.([]uint32); ok {
+			return t, nil
+		}
+		ips, err := toIPs(v)
+		if err != nil {
+			return nil, err
+		}
+		rv := make([]uint32, len(ips))
+		for i, ip := range ips {
+			var ok bool
+			if rv[i], ok = ip4FromIP(ip); !ok {
+				return nil, fmt.Errorf("'%s' is not an IPv4 address", ip)
+			}
+		}
+		return rv, nil
+	case kindIP6s:
+		if t, ok := v.([][]byte); ok {
+			return t, nil
+		}
+		ips, err := toIPs(v)
+		if err != nil {
+			return nil, err
+		}
+		rv := make([][]byte, len(ips))
+		for i, ip := range ips {
+			if ip.To4() != nil {
+				return nil, fmt.Errorf("'%s' is not an IPv6 address", ip)
+			}
+			rv[i] = []byte(ip.To16())
+		}
+		return rv, nil
+	case kindIP4Addresses, kindIP4Routes:
+		entries, err := parseIPEntries(v)
+		if err != nil {
+			return nil, err
+		}
+		return ip4Tuples(entries, k == kindIP4Routes)
+	case kindIP6Addresses, kindIP6Routes:
+		entries, err := parseIPEntries(v)
+		if err != nil {
+			return nil, err
+		}
+		return ip6Tuples(entries, k == kindIP6Routes)
+	case kindAddressData, kindRouteData:
+		if maps, err := toMaps(v); err == nil {
+			for _, m := range maps {
+				for _, key := range []string{"prefix", "metric", "table"} {
+					if value, ok := m[key]; ok {
+						n, err := kindUint32.convert(value)
+						if err != nil {
+							return nil, fmt.Errorf("%s: %v", key, err)
+						}
+						m[key] = n
+					}
+				}
+			}
+			return maps, nil
+		}
+		entries, err := parseIPEntries(v)
+		if err != nil {
+			return nil, err
+		}
+		return ipData(entries, k == kindRouteData), nil
+	case kindMaps:
+		return toMaps(v)
+	case kindStringMap:
+		switch t := v.(type) {
+		case map[string]string:
+			return t, nil
+		case map[string]interface{}, map[string]dbus.Variant:
+			rv := make(map[string]string)
+			for key, value := range toInterfaceMap(t) {
+				s, ok := value.(string)
+				if !ok {
+					return nil, fmt.Errorf("%s: cannot use %T as string", key, value)
+				}
+				rv[key] = s
+			}
+			return rv, nil
+		}
+	}
+
+	return nil, fmt.Errorf("cannot use %T as D-Bus type '%s'", v, k.signature())
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch t := v.(type) {
+	case int:
+		return int64(t), true
+	case int8:
+		return int64(t), true
+	case int16:
+		return int64(t), true
+	case int32:
+		return int64(t), true
+	case int64:
+		return t, true
+	case uint:
+		return int64(t), t <= math.MaxInt64
+	case uint8:
+		return int64(t), true
+	case uint16:
+		return int64(t), true
+	case uint32:
+		return int64(t), true
+	case uint64:
+		return int64(t), t <= math.MaxInt64
+	case float64:
+		return int64(t), t == math.Trunc(t)
+	}
+	return 0, false
+}
+
+func toInterfaces(v interface{}) []interface{} {
+	switch t := v.(type) {
+	case []interface{}:
+		return t
+	case []int:
+		rv := make([]interface{}, len(t))
+		for i, e := range t {
+			rv[i] = e
+		}
+		return rv
+	}
+	return nil
+}
+
+func toInterfaceMap(v interface{}) map[string]interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		return t
+	case map[string]dbus.Variant:
+		rv := make(map[string]interface{}, len(t))
+		for key, value := range t {
+			rv[key] = value.Value()
+		}
+		return rv
+	}
+	return nil
+}
+
+// toMaps converts the decoded or hand written forms of an aa{sv} value to a
+// list of maps holding plain Go values.
+func toMaps(v interface{}) ([]map[string]interface{}, error) {
+	var list []interface{}
+	switch t := v.(type) {
+	case []map[string]interface{}:
+		for _, m := range t {
+			list = append(list, m)
+		}
+	case []map[string]dbus.Variant:
+		for _, m := range t {
+			list = append(list, m)
+		}
+	case []interface{}:
+		list = t
+	default:
+		return nil, fmt.Errorf("cannot use %T as D-Bus type 'aa{sv}'", v)
+	}
+
+	rv := make([]map[string]interface{}, len(list))
+	for i, e := range list {
+		m := toInterfaceMap(e)
+		if m == nil {
+			return nil, fmt.Errorf("cannot use %T as D-Bus type 'a{sv}'", e)
+		}
+		rv[i] = make(map[string]interface{}, len(m))
+		for key, value := range m {
+			if variant, ok := value.(dbus.Variant); ok {
+				value = variant.Value()
+			}
+			rv[i][key] = value
+		}
+	}
+	return rv, nil
+}
+
+func toIPs(v interface{}) ([]net.IP, error) {
+	switch t := v.(type) {
+	case []net.IP:
+		return t, nil
+	case net.IP:
+		return []net.IP{t}, nil
+	case string:
+		return toIPs([]string{t})
+	case []string:
+		rv := make([]net.IP, len(t))
+		for i, s := range t {
+			if rv[i] = net.ParseIP(s); rv[i] == nil {
+				return nil, fmt.Errorf("invalid IP address '%s'", s)
+			}
+		}
+		return rv, nil
+	case []interface{}:
+		var rv []net.IP
+		for _, e := range t {
+			ips, err := toIPs(e)
+			if err != nil {
+				return nil, err
+			}
+			rv = append(rv, ips...)
+		}
+		return rv, nil
+	}
+	return nil, fmt.Errorf("cannot use %T as a list of IP addresses", v)
+}
+
+// ipEntry is the common form of the different representations of an address
+// with its gateway, or of a route with its next hop.
+type ipEntry struct {
+	IP      net.IP
+	Prefix  uint32
+	Gateway net.IP
+	Metric  uint32
+}
+
+// parseIPEntries parses addresses or routes given as [][]uint32 or
+// [][]interface{} tuples, address-data or route-data maps, IP4Address or
+// IP4Route values, or "address/prefix[,gateway[,metric]]" strings.
+func parseIPEntries(v interface{}) ([]ipEntry, error) {
+	var rv []ipEntry
+
+	switch t := v.(type) {
+	case [][]uint32:
+		for _, tuple := range t {
+			if len(tuple) < 3 {
+				return nil, fmt.Errorf("expected an (address, prefix, gateway) tuple, got %d elements", len(tuple))
+			}
+			e := ipEntry{IP: net.ParseIP(ip4ToString(tuple[0])), Prefix: tuple[1]}
+			if tuple[2] != 0 {
+				e.Gateway = net.ParseIP(ip4ToString(tuple[2]))
+			}
+			if len(tuple) > 3 {
+				e.Metric = tuple[3]
+			}
+			rv = append(rv, e)
+		}
+	case [][]interface{}:
+		for _, tuple := range t {
+			if len(tuple) < 3 {
+				return nil, fmt.Errorf("expected an (address, prefix, gateway) tuple, got %d elements", len(tuple))
+			}
+			addr, ok1 := tuple[0].([]byte)
+			prefix, ok2 := tuple[1].(uint32)
+			gw, ok3 := tuple[2].([]byte)
+			if !ok1 || !ok2 || !ok3 || len(addr) != net.IPv6len || len(gw) != net.IPv6len {
+				return nil, fmt.Errorf("expected an (ay, u, ay) tuple, got %v", tuple)
+			}
+			e := ipEntry{IP: net.IP(addr), Prefix: prefix}
+			if !net.IP(gw).IsUnspecified() {
+				e.Gateway = net.IP(gw)
+			}
+			if len(tuple) > 3 {
+				e.Metric, _ = tuple[3].(uint32)
+			}
+			rv = append(rv, e)
+		}
+	case []IP4Address:
+		for _, a := range t {
+			e, err := parseIPEntry(fmt.Sprintf("%s/%d,%s", a.Address, a.Prefix, a.Gateway))
+			if err != nil {
+				return nil, err
+			}
+			rv = append(rv, e)
+		}
+	case []IP4Route:
+		for _, r := range t {
+			e, err := parseIPEntry(fmt.Sprintf("%s/%d,%s,%d", r.Route, r.Prefix, r.NextHop, r.Metric))
+			if err != nil {
+				return nil, err
+			}
+			rv = append(rv, e)
+		}
+	case []string:
+		for _, s := range t {
+			e, err := parseIPEntry(s)
+			if err != nil {
+				return nil, err
+			}
+			rv = append(rv, e)
+		}
+	default:
+		if strs, err := kindStrings.convert(v); err == nil {
+			return parseIPEntries(strs)
+		}
+		maps, err := toMaps(v)
+		if err != nil {
+			return nil, fmt.Errorf("cannot use %T as a list of addresses or routes", v)
+		}
+		for _, m := range maps {
+			addr, _ := m["address"].(string)
+			if dest, ok := m["dest"].(string); ok {
+				addr = dest
+			}
+			e := ipEntry{IP: net.ParseIP(addr)}
+			if e.IP == nil {
+				return nil, fmt.Errorf("invalid IP address '%s'", addr)
+			}
+			if p, err := kindUint32.convert(m["prefix"]); err == nil {
+				e.Prefix = p.(uint32)
+			}
+			if nh, ok := m["next-hop"].(string); ok {
+				e.Gateway = net.ParseIP(nh)
+			}
+			if metric, err := kindUint32.convert(m["metric"]); err == nil {
+				e.Metric = metric.(uint32)
+			}
+			rv = append(rv, e)
+		}
+	}
+
+	return rv, nil
+}
+
+func parseIPEntry(s string) (ipEntry, error) {
+	var e ipEntry
+	parts := strings.Split(s, ",")
+
+	addr := strings.TrimSpace(parts[0])
+	prefix := ""
+	if i := strings.IndexByte(addr, '/'); i >= 0 {
+		addr, prefix = addr[:i], addr[i+1:]
+	}
+	if e.IP = net.ParseIP(addr); e.IP == nil {
+		return e, fmt.Errorf("invalid IP address '%s'", addr)
+	}
+	if prefix == "" {
+		if e.IP.To4() != nil {
+			e.Prefix = 32
+		} else {
+			e.Prefix = 128
+		}
+	} else {
+		p, err := strconv.ParseUint(prefix, 10, 32)
+		if err != nil {
+			return e, fmt.Errorf("invalid prefix '%s'", prefix)
+		}
+		e.Prefix = uint32(p)
+	}
+	if len(parts) > 1 {
+		if gw := strings.TrimSpace(parts[1]); gw != "" {
+			if e.Gateway = net.ParseIP(gw); e.Gateway == nil {
+				return e, fmt.Errorf("invalid gateway '%s'", gw)
+			}
+		}
+	}
+	if len(parts) > 2 {
+		m, err := strconv.ParseUint(strings.TrimSpace(parts[2]), 10, 32)
+		if err != nil {
+			return e, fmt.Errorf("invalid metric '%s'", parts[2])
+		}
+		e.Metric = uint32(m)
+	}
+
+	return e, nil
+}
+
+func ip4Tuples(entries []ipEntry, route bool) ([][]uint32, error) {
+	rv := make([][]uint32, len(entries))
+	for i, e := range entries {
+		addr, ok := ip4FromIP(e.IP)
+		if !ok {
+			return nil, fmt.Errorf("'%s' is not an IPv4 address", e.IP)
+		}
+		if e.Prefix > 32 {
+			return nil, fmt.Errorf("invalid IPv4 prefix %d", e.Prefix)
+		}
+		var gw uint32
+		if e.Gateway != nil {
+			if gw, ok = ip4FromIP(e.Gateway); !ok {
+				return nil, fmt.Errorf("'%s' is not an IPv4 address", e.Gateway)
+			}
+		}
+		rv[i] = []uint32{addr, e.Prefix, gw}
+		if route {
+			rv[i] = append(rv[i], e.Metric)
+		}
+	}
+	return rv, nil
+}
+
+func ip6Tuples(entries []ipEntry, route bool) ([][]interface{}, error) {
+	rv := make([][]interface{}, len(entries))
+	for i, e := range entries {
+		if e.IP.To4() != nil {
+			return nil, fmt.Errorf("'%s' is not an IPv6 address", e.IP)
+		}
+		if e.Prefix > 128 {
+			return nil, fmt.Errorf("invalid IPv6 prefix %d", e.Prefix)
+		}
+		gw := net.IPv6unspecified
+		if e.Gateway != nil {
+			gw = e.Gateway
+		}
+		rv[i] = []interface{}{[]byte(e.IP.To16()), e.Prefix, []byte(gw.To16())}
+		if route {
+			rv[i] = append(rv[i], e.Metric)
+		}
+	}
+	return rv, nil
+}
+
+// ipDataAttributes returns the attributes of an address-data or route-data
+// element other than its address, prefix, next hop and metric, as sorted
+// "name=value" strings.
+func ipDataAttributes(m map[string]interface{}) []string {
+	var attrs []string
+	for _, key := range sortedKeys(m) {
+		switch key {
+		case "address", "dest", "prefix", "next-hop", "metric":
+			continue
+		}
+		attrs = append(attrs, fmt.Sprintf("%s=%v", key, m[key]))
+	}
+	return attrs
+}
+
+func ipData(entries []ipEntry, route bool) []map[string]interface{} {
+	rv := make([]map[string]interface{}, len(entries))
+	for i, e := range entries {
+		if route {
+			rv[i] = map[string]interface{}{"dest": e.IP.String(), "prefix": e.Prefix}
+			if e.Gateway != nil {
+				rv[i]["next-hop"] = e.Gateway.String()
+			}
+			if e.Metric != 0 {
+				rv[i]["metric"] = e.Metric
+			}
+		} else {
+			rv[i] = map[string]interface{}{"address": e.IP.String(), "prefix": e.Prefix}
+		}
+	}
+	return rv
+}
@@ -0,0 +1,247 @@
+package gonetworkmanager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/godbus/dbus"
+)
+
+func TestEncodeSettingsSignatures(t *testing.T) {
+	settings := ConnectionSettings{
+		SettingConnection: {"id": "wifi", "uuid": testUUID, "type": SettingWireless, "autoconnect": "true"},
+		SettingWireless:   {"ssid": "home", "mac-address": "00:11:22:33:44:55"},
+		SettingIP4Config: {
+			"method":       "manual",
+			"dns":          []string{"8.8.8.8", "1.1.1.1"},
+			"address-data": []string{"192.168.1.2/24"},
+			"route-metric": 100,
+		},
+		SettingIP6Config: {"method": "auto", "dns": []string{"2001:db8::1"}},
+	}
+
+	encoded, err := EncodeSettings(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name, key, signature string
+	}{
+		{SettingConnection, "autoconnect", "b"},
+		{SettingWireless, "ssid", "ay"},
+		{SettingWireless, "mac-address", "ay"},
+		{SettingIP4Config, "dns", "au"},
+		{SettingIP4Config, "address-data", "aa{sv}"},
+		{SettingIP4Config, "route-metric", "x"},
+		{SettingIP6Config, "dns", "aay"},
+	}
+	for _, tt := range tests {
+		v, ok := encoded[tt.name][tt.key]
+		if !ok {
+			t.Errorf("%s.%s: missing", tt.name, tt.key)
+			continue
+		}
+		if got := v.Signature().String(); got != tt.signature {
+			t.Errorf("%s.%s: signature %s, want %s", tt.name, tt.key, got, tt.signature)
+		}
+	}
+
+	if dns := encoded[SettingIP4Config]["dns"].Value().([]uint32); dns[0] != 0x08080808 {
+		t.Errorf("ipv4.dns: got %#x, want 0x08080808", dns[0])
+	}
+}
+
+func TestEncodeSettingsErrors(t *testing.T) {
+	settings := ConnectionSettings{
+		SettingConnection: {"id": "wired", "uuid": testUUID, "type": SettingWired},
+		SettingIP4Config:  {"dns": []string{"2001:db8::1"}, "route-table": -1},
+	}
+	_, err := EncodeSettings(settings)
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("got %v, want two ValidationErrors", err)
+	}
+	if errs[0].Path != "ipv4.dns" || errs[1].Path != "ipv4.route-table" {
+		t.Errorf("got errors on %s and %s", errs[0].Path, errs[1].Path)
+	}
+}
+
+func TestEncodeSettingsLegacyAddresses(t *testing.T) {
+	tests := []struct {
+		name      string
+		ip        map[string]interface{}
+		addresses bool
+		data      bool
+	}{
+		{
+			name:      "legacy only",
+			ip:        map[string]interface{}{"method": "manual", "addresses": []string{"10.0.0.2/8"}},
+			addresses: true,
+		},
+		{
+			name: "data only",
+			ip:   map[string]interface{}{"method": "manual", "address-data": []string{"10.0.0.2/8"}},
+			data: true,
+		},
+		{
+			name: "both, as returned by NetworkManager",
+			ip: map[string]interface{}{
+				"method":       "manual",
+				"addresses":    []string{"10.0.0.2/8"},
+				"address-data": []string{"10.0.0.3/8"},
+				"routes":       []string{"10.1.0.0/16,10.0.0.1"},
+				"route-data":   []string{"10.2.0.0/16,10.0.0.1"},
+			},
+			data: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := EncodeSettings(ConnectionSettings{SettingIP4Config: tt.ip})
+			if err != nil {
+				t.Fatal(err)
+			}
+			ip := encoded[SettingIP4Config]
+			if _, ok := ip["addresses"]; ok != tt.addresses {
+				t.Errorf("addresses sent: %v, want %v", ok, tt.addresses)
+			}
+			if _, ok := ip["address-data"]; ok != tt.data {
+				t.Errorf("address-data sent: %v, want %v", ok, tt.data)
+			}
+			if _, ok := ip["routes"]; ok && tt.data {
+				t.Error("routes sent along with route-data")
+			}
+		})
+	}
+}
+
+func TestDecodeSettings(t *testing.T) {
+	raw := map[string]map[string]dbus.Variant{
+		SettingConnection: {
+			"id":          dbus.MakeVariant("wired"),
+			"autoconnect": dbus.MakeVariant(false),
+		},
+		SettingIP4Config: {
+			"method": dbus.MakeVariant("manual"),
+			"address-data": dbus.MakeVariant([]map[string]dbus.Variant{
+				{"address": dbus.MakeVariant("192.168.1.2"), "prefix": dbus.MakeVariant(uint32(24))},
+			}),
+			"dns": dbus.MakeVariant([]uint32{0x08080808}),
+		},
+	}
+
+	settings := DecodeSettings(raw)
+	want := ConnectionSettings{
+		SettingConnection: {"id": "wired", "autoconnect": false},
+		SettingIP4Config: {
+			"method": "manual",
+			"address-data": []map[string]interface{}{
+				{"address": "192.168.1.2", "prefix": uint32(24)},
+			},
+			"dns": []uint32{0x08080808},
+		},
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("got %#v, want %#v", settings, want)
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	settings := ConnectionSettings{
+		SettingConnection: {"id": "wired", "uuid": testUUID, "type": SettingWired, "autoconnect-priority": int32(5)},
+		SettingWired:      {"mac-address": []byte{0, 0x11, 0x22, 0x33, 0x44, 0x55}},
+		SettingIP4Config: {
+			"method":       "manual",
+			"address-data": []map[string]interface{}{{"address": "192.168.1.2", "prefix": uint32(24)}},
+			"dns":          []uint32{0x01010101},
+			"dns-search":   []string{"example.com"},
+		},
+		SettingVPN: {"data": map[string]string{"remote": "vpn.example.com"}},
+	}
+
+	encoded, err := EncodeSettings(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded := DecodeSettings(encoded); !reflect.DeepEqual(decoded, settings) {
+		t.Errorf("got %#v, want %#v", decoded, settings)
+	}
+}
+
+func TestEncodeSettingsAddressGateway(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]interface{}
+		gateway  interface{}
+	}{
+		{"ipv4", map[string]interface{}{"address-data": []string{"10.0.0.2/8,10.0.0.1", "10.1.0.2/16,10.1.0.1"}}, "10.0.0.1"},
+		{"explicit gateway", map[string]interface{}{"address-data": []string{"10.0.0.2/8,10.0.0.1"}, "gateway": "10.0.0.254"}, "10.0.0.254"},
+		{"no gateway", map[string]interface{}{"address-data": []string{"10.0.0.2/8"}}, nil},
+	}
+	for _, tt := range tests {
+		encoded, err := EncodeSettings(ConnectionSettings{SettingIP4Config: tt.settings})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		var gateway interface{}
+		if v, ok := encoded[SettingIP4Config]["gateway"]; ok {
+			gateway = v.Value()
+		}
+		if gateway != tt.gateway {
+			t.Errorf("%s: gateway %v, want %v", tt.name, gateway, tt.gateway)
+		}
+	}
+
+	encoded, err := EncodeSettings(ConnectionSettings{SettingIP6Config: {"address-data": []string{"2001:db8::2/64,2001:db8::1"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gw := encoded[SettingIP6Config]["gateway"].Value(); gw != "2001:db8::1" {
+		t.Errorf("ipv6: gateway %v, want 2001:db8::1", gw)
+	}
+}
+
+func TestEncodeSettingsNested(t *testing.T) {
+	peers := []map[string]interface{}{{
+		"public-key":           "xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=",
+		"endpoint":             "vpn.example.com:51820",
+		"allowed-ips":          []string{"10.0.0.0/24"},
+		"persistent-keepalive": 25,
+		"preshared-key-flags":  1,
+	}}
+	encoded, err := EncodeSettings(ConnectionSettings{SettingWireGuard: {"peers": peers}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v := encoded[SettingWireGuard]["peers"]
+	if got := v.Signature().String(); got != "aa{sv}" {
+		t.Fatalf("wireguard.peers: signature %s, want aa{sv}", got)
+	}
+	peer := v.Value().([]map[string]dbus.Variant)[0]
+	tests := map[string]string{
+		"public-key":           "s",
+		"endpoint":             "s",
+		"allowed-ips":          "as",
+		"persistent-keepalive": "u",
+		"preshared-key-flags":  "u",
+	}
+	for key, signature := range tests {
+		if got := peer[key].Signature().String(); got != signature {
+			t.Errorf("wireguard.peers[0].%s: signature %s, want %s", key, got, signature)
+		}
+	}
+	if _, ok := peers[0]["persistent-keepalive"].(int); !ok {
+		t.Error("EncodeSettings modified its argument")
+	}
+
+	_, err = EncodeSettings(ConnectionSettings{SettingWireGuard: {"peers": []map[string]interface{}{
+		{"public-key": "xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=", "persistent-keepalive": -1},
+	}}})
+	errs, ok := err.(ValidationErrors)
+	if !ok || len(errs) != 1 || errs[0].Path != "wireguard.peers[0].persistent-keepalive" {
+		t.Errorf("got error %v, want one for wireguard.peers[0].persistent-keepalive", err)
+	}
+}
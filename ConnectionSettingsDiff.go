@@ -0,0 +1,303 @@
+package gonetworkmanager
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+)
+
+//go:generate stringer -type=SettingsChangeType
+type SettingsChangeType uint32
+
+const (
+	SettingsChangeAdded   SettingsChangeType = 0
+	SettingsChangeRemoved SettingsChangeType = 1
+	SettingsChangeChanged SettingsChangeType = 2
+)
+
+// SettingsChange is a single difference between two ConnectionSettings. Key
+// is empty when a whole setting was added or removed, in which case Old or New
+// holds its map of keys.
+type SettingsChange struct {
+	Type    SettingsChangeType
+	Setting string
+	Key     string
+	Old     interface{}
+	New     interface{}
+}
+
+// Path returns the "setting.key" path of the change, or just the setting name
+// for a whole setting.
+func (c SettingsChange) Path() string {
+	if c.Key == "" {
+		return c.Setting
+	}
+	return c.Setting + "." + c.Key
+}
+
+func (c SettingsChange) String() string {
+	switch c.Type {
+	case SettingsChangeAdded:
+		return fmt.Sprintf("+ %s: %s", c.Path(), c.format(c.New))
+	case SettingsChangeRemoved:
+		return fmt.Sprintf("- %s: %s", c.Path(), c.format(c.Old))
+	}
+	return fmt.Sprintf("~ %s: %s -> %s", c.Path(), c.format(c.Old), c.format(c.New))
+}
+
+// format returns a human readable form of a value of the changed key.
+func (c SettingsChange) format(v interface{}) string {
+	kind, ok := settingsSchema[c.Setting][c.Key]
+	if !ok {
+		return fmt.Sprint(v)
+	}
+	switch kind {
+	case kindBytes:
+		return fmt.Sprintf("%q", v)
+	case kindMAC:
+		if b, ok := v.([]byte); ok {
+			return net.HardwareAddr(b).String()
+		}
+	}
+	if list, ok := settingValueStrings(kind, v); ok {
+		return fmt.Sprint(list)
+	}
+	return fmt.Sprint(v)
+}
+
+// SettingsDiff is the list of changes returned by Diff, sorted by path.
+type SettingsDiff []SettingsChange
+
+func (d SettingsDiff) String() string {
+	var buf bytes.Buffer
+	for _, c := range d {
+		buf.WriteString(c.String())
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// MergeConflict is a key that was changed differently in both the local and
+// the remote settings passed to Merge.
+type MergeConflict struct {
+	Path   string
+	Base   interface{}
+	Local  interface{}
+	Remote interface{}
+}
+
+// Diff returns the changes from a to b. Values are compared after converting
+// them as EncodeSettings does, so an SSID given as a string equals the same
+// SSID as []byte, and addresses and routes compare equal in any of their
+// representations. Lists whose order NetworkManager ignores, such as routes
+// or allowed ciphers, are compared as sets. Secrets are compared like any
+// other key, so remove them first when diffing against GetSettings.
+func Diff(a, b ConnectionSettings) SettingsDiff {
+	a, _ = normalizeSettings(a)
+	b, _ = normalizeSettings(b)
+
+	var diff SettingsDiff
+	names := sortedSettingNames(a)
+	for _, name := range sortedSettingNames(b) {
+		if _, ok := a[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ga, inA := a[name]
+		gb, inB := b[name]
+		if !inA {
+			diff = append(diff, SettingsChange{Type: SettingsChangeAdded, Setting: name, New: gb})
+			continue
+		}
+		if !inB {
+			diff = append(diff, SettingsChange{Type: SettingsChangeRemoved, Setting: name, Old: ga})
+			continue
+		}
+
+		keys := sortedKeys(ga)
+		for _, key := range sortedKeys(gb) {
+			if _, ok := ga[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			va, inA := ga[key]
+			vb, inB := gb[key]
+			switch {
+			case !inA:
+				diff = append(diff, SettingsChange{Type: SettingsChangeAdded, Setting: name, Key: key, New: vb})
+			case !inB:
+				diff = append(diff, SettingsChange{Type: SettingsChangeRemoved, Setting: name, Key: key, Old: va})
+			case !settingValuesEqual(name, key, va, vb):
+				diff = append(diff, SettingsChange{Type: SettingsChangeChanged, Setting: name, Key: key, Old: va, New: vb})
+			}
+		}
+	}
+
+	return diff
+}
+
+// Merge performs a three-way merge: the changes made from base to local are
+// applied on top of remote. Typically base is the centrally managed profile
+// the local one was derived from, local the profile returned by
+// Connection.GetSettings with its local overrides, and remote the new central
+// profile. Keys changed differently on both sides are returned as conflicts
+// and resolved in favour of local, except for keys changed locally in a
+// setting that was removed remotely, which stays removed.
+func Merge(base, local, remote ConnectionSettings) (ConnectionSettings, []MergeConflict) {
+	remoteDiff := Diff(base, remote)
+	remoteChanges := make(map[string]SettingsChange, len(remoteDiff))
+	for _, c := range remoteDiff {
+		remoteChanges[c.Path()] = c
+	}
+
+	merged, _ := normalizeSettings(remote)
+	var conflicts []MergeConflict
+
+	for _, c := range Diff(base, local) {
+		r, changedRemotely := remoteChanges[c.Path()]
+		if !changedRemotely && c.Key != "" {
+			r, changedRemotely = remoteChanges[c.Setting]
+		}
+		conflict := changedRemotely && !sameSettingsChange(c, r)
+		if c.Key == "" && !changedRemotely {
+			// A whole setting added or removed locally conflicts with any
+			// other change to its keys.
+			for _, rc := range remoteDiff {
+				if rc.Setting == c.Setting && !sameSettingsChange(c, rc) {
+					conflict = true
+					break
+				}
+			}
+		}
+		if conflict {
+			conflicts = append(conflicts, MergeConflict{
+				Path:   c.Path(),
+				Base:   c.Old,
+				Local:  c.New,
+				Remote: valueAt(merged, c.Setting, c.Key),
+			})
+		}
+
+		switch {
+		case changedRemotely && c.Key != "" && r.Key == "" && r.Type == SettingsChangeRemoved:
+			// Recreating the setting with only the locally changed key would
+			// leave it incomplete.
+			continue
+		case c.Key == "" && c.Type == SettingsChangeRemoved:
+			delete(merged, c.Setting)
+		case c.Key == "":
+			merged[c.Setting] = copySetting(c.New.(map[string]interface{}))
+		case c.Type == SettingsChangeRemoved:
+			delete(merged[c.Setting], c.Key)
+		default:
+			if merged[c.Setting] == nil {
+				merged[c.Setting] = make(map[string]interface{})
+			}
+			merged[c.Setting][c.Key] = c.New
+		}
+	}
+
+	return merged, conflicts
+}
+
+// sameSettingsChange reports whether the local change c and the remote change
+// r have the same outcome.
+func sameSettingsChange(c, r SettingsChange) bool {
+	return c.Type == r.Type && settingValuesEqual(c.Setting, c.Key, c.New, r.New)
+}
+
+func valueAt(settings ConnectionSettings, name, key string) interface{} {
+	group, ok := settings[name]
+	if !ok {
+		return nil
+	}
+	if key == "" {
+		return group
+	}
+	return group[key]
+}
+
+func copySetting(group map[string]interface{}) map[string]interface{} {
+	rv := make(map[string]interface{}, len(group))
+	for k, v := range group {
+		rv[k] = v
+	}
+	return rv
+}
+
+// settingValuesEqual compares two values of a key after normalization.
+func settingValuesEqual(name, key string, a, b interface{}) bool {
+	if kind, ok := settingsSchema[name][key]; ok {
+		la, okA := settingValueStrings(kind, a)
+		lb, okB := settingValueStrings(kind, b)
+		if okA && okB {
+			if isUnorderedSettingKey(name, key) {
+				sort.Strings(la)
+				sort.Strings(lb)
+			}
+			return reflect.DeepEqual(la, lb)
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// settingValueStrings returns the elements of a list value as comparable
+// strings. It returns false for kinds that are not lists.
+func settingValueStrings(kind settingKind, v interface{}) ([]string, bool) {
+	list := []string{}
+
+	switch kind {
+	case kindBytes, kindMAC:
+		b, ok := v.([]byte)
+		return []string{string(b)}, ok
+	case kindStrings:
+		s, ok := v.([]string)
+		return append(list, s...), ok
+	case kindUint32s:
+		ns, ok := v.([]uint32)
+		for _, n := range ns {
+			list = append(list, fmt.Sprint(n))
+		}
+		return list, ok
+	case kindIP4s:
+		ips, ok := v.([]uint32)
+		for _, ip := range ips {
+			list = append(list, ip4ToString(ip))
+		}
+		return list, ok
+	case kindIP6s:
+		ips, ok := v.([][]byte)
+		for _, ip := range ips {
+			list = append(list, net.IP(ip).String())
+		}
+		return list, ok
+	case kindIP4Addresses, kindIP4Routes, kindIP6Addresses, kindIP6Routes, kindAddressData, kindRouteData:
+		entries, err := parseIPEntries(v)
+		if err != nil {
+			return nil, false
+		}
+		// address-data and route-data elements may carry attributes such as
+		// the route table, which take part in the comparison.
+		maps, _ := v.([]map[string]interface{})
+		for i, e := range entries {
+			s := fmt.Sprintf("%s/%d,%s,%d", e.IP, e.Prefix, e.Gateway, e.Metric)
+			if i < len(maps) {
+				for _, attr := range ipDataAttributes(maps[i]) {
+					s += "," + attr
+				}
+			}
+			list = append(list, s)
+		}
+		return list, true
+	}
+
+	return nil, false
+}
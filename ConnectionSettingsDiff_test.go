@@ -0,0 +1,202 @@
+package gonetworkmanager
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	a := ConnectionSettings{
+		SettingConnection: {"id": "wifi", "uuid": testUUID, "type": SettingWireless},
+		SettingWireless:   {"ssid": "home", "mode": "infrastructure"},
+		SettingIP4Config: {
+			"method":     "auto",
+			"route-data": []string{"10.0.0.0/8,192.168.1.1", "172.16.0.0/12"},
+		},
+		"proxy": {"method": "none"},
+	}
+	b := ConnectionSettings{
+		SettingConnection: {"id": "wifi", "uuid": testUUID, "type": SettingWireless, "autoconnect": false},
+		SettingWireless:   {"ssid": []byte("home")},
+		SettingIP4Config: {
+			"method":     "manual",
+			"route-data": []string{"172.16.0.0/12", "10.0.0.0/8,192.168.1.1"},
+		},
+		SettingIP6Config: {"method": "ignore"},
+	}
+
+	var got []string
+	for _, c := range Diff(a, b) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"- 802-11-wireless.mode: infrastructure",
+		"+ connection.autoconnect: false",
+		"~ ipv4.method: auto -> manual",
+		"+ ipv6: map[method:ignore]",
+		"- proxy: map[method:none]",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got\n%q\nwant\n%q", got, want)
+	}
+
+	if d := Diff(a, a); len(d) != 0 {
+		t.Errorf("diff with itself: %v", d)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := ConnectionSettings{
+		SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+		SettingIP4Config:  {"method": "auto", "dns-search": []string{"example.com"}},
+		SettingIP6Config:  {"method": "auto"},
+	}
+
+	tests := []struct {
+		name          string
+		local, remote ConnectionSettings
+		merged        ConnectionSettings
+		conflicts     []string
+	}{
+		{
+			name: "independent changes",
+			local: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired, "autoconnect": false},
+				SettingIP4Config:  {"method": "auto", "dns-search": []string{"example.com"}},
+				SettingIP6Config:  {"method": "auto"},
+			},
+			remote: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "auto", "dns-search": []string{"corp.example.com"}},
+				SettingIP6Config:  {"method": "auto"},
+			},
+			merged: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired, "autoconnect": false},
+				SettingIP4Config:  {"method": "auto", "dns-search": []string{"corp.example.com"}},
+				SettingIP6Config:  {"method": "auto"},
+			},
+		},
+		{
+			name: "same key changed on both sides",
+			local: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "manual", "dns-search": []string{"example.com"}},
+				SettingIP6Config:  {"method": "auto"},
+			},
+			remote: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "disabled", "dns-search": []string{"example.com"}},
+				SettingIP6Config:  {"method": "auto"},
+			},
+			merged: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "manual", "dns-search": []string{"example.com"}},
+				SettingIP6Config:  {"method": "auto"},
+			},
+			conflicts: []string{"ipv4.method"},
+		},
+		{
+			name: "local key in setting removed remotely",
+			local: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "auto", "dns-search": []string{"example.com"}},
+				SettingIP6Config:  {"method": "ignore"},
+			},
+			remote: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "auto", "dns-search": []string{"example.com"}},
+			},
+			merged: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "auto", "dns-search": []string{"example.com"}},
+			},
+			conflicts: []string{"ipv6.method"},
+		},
+		{
+			name: "setting removed locally and changed remotely",
+			local: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "auto", "dns-search": []string{"example.com"}},
+			},
+			remote: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "auto", "dns-search": []string{"example.com"}},
+				SettingIP6Config:  {"method": "manual", "may-fail": false},
+			},
+			merged: ConnectionSettings{
+				SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "auto", "dns-search": []string{"example.com"}},
+			},
+			conflicts: []string{"ipv6"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts := Merge(base, tt.local, tt.remote)
+			if d := Diff(merged, tt.merged); len(d) != 0 {
+				t.Errorf("merged settings differ:\n%s", d)
+			}
+			var paths []string
+			for _, c := range conflicts {
+				paths = append(paths, c.Path)
+			}
+			if !reflect.DeepEqual(paths, tt.conflicts) {
+				t.Errorf("got conflicts %v, want %v", paths, tt.conflicts)
+			}
+		})
+	}
+}
+
+func TestMergeRemovedSetting(t *testing.T) {
+	base := ConnectionSettings{
+		SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+		SettingIP6Config:  {"method": "auto", "may-fail": true, "dns-search": []string{"example.com"}},
+	}
+	local := ConnectionSettings{
+		SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+	}
+	// Removing dns-search agrees with the local removal, changing method does
+	// not.
+	remote := ConnectionSettings{
+		SettingConnection: {"id": "office", "uuid": testUUID, "type": SettingWired},
+		SettingIP6Config:  {"method": "manual", "may-fail": true},
+	}
+
+	for i := 0; i < 20; i++ {
+		merged, conflicts := Merge(base, local, remote)
+		if len(conflicts) != 1 || conflicts[0].Path != SettingIP6Config {
+			t.Fatalf("got conflicts %v, want one for ipv6", conflicts)
+		}
+		if _, ok := merged[SettingIP6Config]; ok {
+			t.Fatalf("ipv6 not removed: %v", merged)
+		}
+	}
+}
+
+func TestDiffRouteAttributes(t *testing.T) {
+	routes := func(table uint32) ConnectionSettings {
+		return ConnectionSettings{
+			SettingIP4Config: {"route-data": []map[string]interface{}{
+				{"dest": "10.0.0.0", "prefix": 8, "next-hop": "192.168.1.1", "table": table, "onlink": true},
+			}},
+		}
+	}
+
+	if d := Diff(routes(100), routes(100)); len(d) != 0 {
+		t.Errorf("equal routes differ: %v", d)
+	}
+	d := Diff(routes(100), routes(200))
+	if len(d) != 1 || d[0].Path() != "ipv4.route-data" {
+		t.Fatalf("got %v, want a change of ipv4.route-data", d)
+	}
+
+	// An unchanged local copy must not keep the old table.
+	merged, conflicts := Merge(routes(100), routes(100), routes(200))
+	if len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts %v", conflicts)
+	}
+	if d := Diff(merged, routes(200)); len(d) != 0 {
+		t.Errorf("merged settings differ from remote:\n%s", d)
+	}
+}
@@ -0,0 +1,459 @@
+package gonetworkmanager
+
+import "reflect"
+
+// Names of the setting groups of a ConnectionSettings map.
+const (
+	SettingConnection       = "connection"
+	SettingWired            = "802-3-ethernet"
+	SettingWireless         = "802-11-wireless"
+	SettingWirelessSecurity = "802-11-wireless-security"
+	Setting8021x            = "802-1x"
+	SettingIP4Config        = "ipv4"
+	SettingIP6Config        = "ipv6"
+	SettingVPN              = "vpn"
+	SettingWireGuard        = "wireguard"
+	SettingBond             = "bond"
+	SettingBridge           = "bridge"
+	SettingBridgePort       = "bridge-port"
+	SettingVLAN             = "vlan"
+	SettingTeam             = "team"
+	SettingTeamPort         = "team-port"
+	SettingGSM              = "gsm"
+	SettingCDMA             = "cdma"
+	SettingBluetooth        = "bluetooth"
+	SettingWifiP2P          = "wifi-p2p"
+)
+
+// settingKind describes the value a setting key takes in a ConnectionSettings
+// map, which in turn determines its D-Bus signature. Several kinds share a
+// signature but accept different convenience values, see convert.
+type settingKind int
+
+const (
+	kindString       settingKind = iota // s
+	kindBool                            // b
+	kindInt32                           // i
+	kindUint32                          // u
+	kindInt64                           // x
+	kindUint64                          // t
+	kindBytes                           // ay
+	kindMAC                             // ay, hardware address
+	kindStrings                         // as
+	kindUint32s                         // au
+	kindIP4s                            // au, IPv4 addresses
+	kindIP6s                            // aay, IPv6 addresses
+	kindIP4Addresses                    // aau, (address, prefix, gateway)
+	kindIP4Routes                       // aau, (route, prefix, next-hop, metric)
+	kindIP6Addresses                    // a(ayuay)
+	kindIP6Routes                       // a(ayuayu)
+	kindAddressData                     // aa{sv}, address and prefix
+	kindRouteData                       // aa{sv}, dest, prefix, next-hop and metric
+	kindMaps                            // aa{sv}
+	kindStringMap                       // a{ss}
+)
+
+// signature returns the D-Bus signature of values of the kind.
+func (k settingKind) signature() string {
+	switch k {
+	case kindString:
+		return "s"
+	case kindBool:
+		return "b"
+	case kindInt32:
+		return "i"
+	case kindUint32:
+		return "u"
+	case kindInt64:
+		return "x"
+	case kindUint64:
+		return "t"
+	case kindBytes, kindMAC:
+		return "ay"
+	case kindStrings:
+		return "as"
+	case kindUint32s, kindIP4s:
+		return "au"
+	case kindIP6s:
+		return "aay"
+	case kindIP4Addresses, kindIP4Routes:
+		return "aau"
+	case kindIP6Addresses:
+		return "a(ayuay)"
+	case kindIP6Routes:
+		return "a(ayuayu)"
+	case kindAddressData, kindRouteData, kindMaps:
+		return "aa{sv}"
+	case kindStringMap:
+		return "a{ss}"
+	}
+	return ""
+}
+
+// secretSettingKeys lists the keys holding secrets. NetworkManager never
+// returns them from GetSettings.
+var secretSettingKeys = map[string][]string{
+	SettingWirelessSecurity: {"psk", "leap-password", "wep-key0", "wep-key1", "wep-key2", "wep-key3"},
+	Setting8021x:            {"password", "password-raw", "private-key-password", "pin"},
+	SettingVPN:              {"secrets"},
+	SettingWireGuard:        {"private-key"},
+	SettingGSM:              {"password", "pin"},
+	SettingCDMA:             {"password"},
+}
+
+func isSecretSettingKey(name, key string) bool {
+	return contains(secretSettingKeys[name], key)
+}
+
+// nestedSecretSettingKeys lists the secret keys of the elements of aa{sv}
+// setting keys, such as the preshared keys of WireGuard peers.
+var nestedSecretSettingKeys = map[string][]string{
+	SettingWireGuard + ".peers": {"preshared-key"},
+}
+
+// withoutNestedSecrets returns a copy of the value of name.key without the
+// nested secret keys of its elements.
+func withoutNestedSecrets(name, key string, v interface{}) interface{} {
+	secrets, ok := nestedSecretSettingKeys[name+"."+key]
+	maps, isMaps := v.([]map[string]interface{})
+	if !ok || !isMaps {
+		return v
+	}
+	rv := make([]map[string]interface{}, len(maps))
+	for i, m := range maps {
+		rv[i] = copySetting(m)
+		for _, secret := range secrets {
+			delete(rv[i], secret)
+		}
+	}
+	return rv
+}
+
+// unorderedSettingKeys lists the list keys whose order NetworkManager
+// ignores.
+var unorderedSettingKeys = map[string][]string{
+	SettingConnection:       {"permissions", "secondaries"},
+	SettingWired:            {"mac-address-blacklist", "s390-subchannels"},
+	SettingWireless:         {"mac-address-blacklist", "seen-bssids"},
+	SettingWirelessSecurity: {"proto", "pairwise", "group"},
+	Setting8021x:            {"eap", "altsubject-matches"},
+	SettingIP4Config:        {"routes", "route-data", "dns-options"},
+	SettingIP6Config:        {"routes", "route-data", "dns-options"},
+}
+
+func isUnorderedSettingKey(name, key string) bool {
+	return contains(unorderedSettingKeys[name], key)
+}
+
+// settingDefaults lists the keys whose default is not the zero value of
+// their kind. GetSettings omits keys that have their default value.
+var settingDefaults = map[string]map[string]interface{}{
+	SettingConnection: {
+		"autoconnect":         true,
+		"autoconnect-retries": int32(-1),
+		"autoconnect-slaves":  int32(-1),
+		"auth-retries":        int32(-1),
+		"lldp":                int32(-1),
+		"mdns":                int32(-1),
+		"llmnr":               int32(-1),
+		"wait-device-timeout": int32(-1),
+	},
+	SettingIP4Config: {
+		"route-metric":       int64(-1),
+		"dhcp-send-hostname": true,
+		"may-fail":           true,
+		"dad-timeout":        int32(-1),
+	},
+	SettingIP6Config: {
+		"route-metric":       int64(-1),
+		"dhcp-send-hostname": true,
+		"may-fail":           true,
+		"ip6-privacy":        int32(-1),
+	},
+}
+
+// isDefaultSettingValue reports whether the normalized value v of a key known
+// to the schema is its default, so that GetSettings leaves the key out.
+func isDefaultSettingValue(name, key string, v interface{}) bool {
+	if _, ok := settingsSchema[name][key]; !ok {
+		return false
+	}
+	if def, ok := settingDefaults[name][key]; ok {
+		return settingValuesEqual(name, key, v, def)
+	}
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return true
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map:
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
+
+// settingsSchema maps setting names to the kinds of the keys NetworkManager
+// knows about. Keys missing from the schema are passed through untouched.
+var settingsSchema = map[string]map[string]settingKind{
+	SettingConnection: {
+		"id":                   kindString,
+		"uuid":                 kindString,
+		"stable-id":            kindString,
+		"type":                 kindString,
+		"interface-name":       kindString,
+		"autoconnect":          kindBool,
+		"autoconnect-priority": kindInt32,
+		"autoconnect-retries":  kindInt32,
+		"autoconnect-slaves":   kindInt32,
+		"auth-retries":         kindInt32,
+		"timestamp":            kindUint64,
+		"read-only":            kindBool,
+		"permissions":          kindStrings,
+		"zone":                 kindString,
+		"master":               kindString,
+		"slave-type":           kindString,
+		"secondaries":          kindStrings,
+		"gateway-ping-timeout": kindUint32,
+		"metered":              kindInt32,
+		"lldp":                 kindInt32,
+		"mdns":                 kindInt32,
+		"llmnr":                kindInt32,
+		"multi-connect":        kindInt32,
+		"wait-device-timeout":  kindInt32,
+	},
+	SettingWired: {
+		"port":                      kindString,
+		"speed":                     kindUint32,
+		"duplex":                    kindString,
+		"auto-negotiate":            kindBool,
+		"mac-address":               kindMAC,
+		"cloned-mac-address":        kindMAC,
+		"assigned-mac-address":      kindString,
+		"generate-mac-address-mask": kindString,
+		"mac-address-blacklist":     kindStrings,
+		"mtu":                       kindUint32,
+		"s390-subchannels":          kindStrings,
+		"s390-nettype":              kindString,
+		"s390-options":              kindStringMap,
+		"wake-on-lan":               kindUint32,
+		"wake-on-lan-password":      kindString,
+	},
+	SettingWireless: {
+		"ssid":                      kindBytes,
+		"mode":                      kindString,
+		"band":                      kindString,
+		"channel":                   kindUint32,
+		"bssid":                     kindMAC,
+		"rate":                      kindUint32,
+		"tx-power":                  kindUint32,
+		"mac-address":               kindMAC,
+		"cloned-mac-address":        kindMAC,
+		"assigned-mac-address":      kindString,
+		"generate-mac-address-mask": kindString,
+		"mac-address-blacklist":     kindStrings,
+		"mac-address-randomization": kindUint32,
+		"mtu":                       kindUint32,
+		"seen-bssids":               kindStrings,
+		"hidden":                    kindBool,
+		"powersave":                 kindUint32,
+		"wake-on-wlan":              kindUint32,
+		"security":                  kindString,
+	},
+	SettingWirelessSecurity: {
+		"key-mgmt":            kindString,
+		"wep-tx-keyidx":       kindUint32,
+		"auth-alg":            kindString,
+		"proto":               kindStrings,
+		"pairwise":            kindStrings,
+		"group":               kindStrings,
+		"pmf":                 kindInt32,
+		"leap-username":       kindString,
+		"leap-password":       kindString,
+		"leap-password-flags": kindUint32,
+		"wep-key0":            kindString,
+		"wep-key1":            kindString,
+		"wep-key2":            kindString,
+		"wep-key3":            kindString,
+		"wep-key-flags":       kindUint32,
+		"wep-key-type":        kindUint32,
+		"psk":                 kindString,
+		"psk-flags":           kindUint32,
+		"wps-method":          kindUint32,
+		"fils":                kindInt32,
+	},
+	Setting8021x: {
+		"eap":                        kindStrings,
+		"identity":                   kindString,
+		"anonymous-identity":         kindString,
+		"pac-file":                   kindString,
+		"ca-cert":                    kindBytes,
+		"ca-path":                    kindString,
+		"subject-match":              kindString,
+		"altsubject-matches":         kindStrings,
+		"domain-suffix-match":        kindString,
+		"client-cert":                kindBytes,
+		"phase1-peapver":             kindString,
+		"phase1-peaplabel":           kindString,
+		"phase1-fast-provisioning":   kindString,
+		"phase2-auth":                kindString,
+		"phase2-autheap":             kindString,
+		"phase2-ca-cert":             kindBytes,
+		"phase2-client-cert":         kindBytes,
+		"password":                   kindString,
+		"password-flags":             kindUint32,
+		"password-raw":               kindBytes,
+		"password-raw-flags":         kindUint32,
+		"private-key":                kindBytes,
+		"private-key-password":       kindString,
+		"private-key-password-flags": kindUint32,
+		"phase2-private-key":         kindBytes,
+		"pin":                        kindString,
+		"pin-flags":                  kindUint32,
+		"system-ca-certs":            kindBool,
+		"auth-timeout":               kindInt32,
+	},
+	SettingIP4Config: {
+		"method":             kindString,
+		"dns":                kindIP4s,
+		"dns-search":         kindStrings,
+		"dns-options":        kindStrings,
+		"dns-priority":       kindInt32,
+		"addresses":          kindIP4Addresses,
+		"address-data":       kindAddressData,
+		"gateway":            kindString,
+		"routes":             kindIP4Routes,
+		"route-data":         kindRouteData,
+		"route-metric":       kindInt64,
+		"route-table":        kindUint32,
+		"ignore-auto-routes": kindBool,
+		"ignore-auto-dns":    kindBool,
+		"dhcp-client-id":     kindString,
+		"dhcp-timeout":       kindInt32,
+		"dhcp-send-hostname": kindBool,
+		"dhcp-hostname":      kindString,
+		"dhcp-fqdn":          kindString,
+		"never-default":      kindBool,
+		"may-fail":           kindBool,
+		"dad-timeout":        kindInt32,
+	},
+	SettingIP6Config: {
+		"method":             kindString,
+		"dns":                kindIP6s,
+		"dns-search":         kindStrings,
+		"dns-options":        kindStrings,
+		"dns-priority":       kindInt32,
+		"addresses":          kindIP6Addresses,
+		"address-data":       kindAddressData,
+		"gateway":            kindString,
+		"routes":             kindIP6Routes,
+		"route-data":         kindRouteData,
+		"route-metric":       kindInt64,
+		"route-table":        kindUint32,
+		"ignore-auto-routes": kindBool,
+		"ignore-auto-dns":    kindBool,
+		"dhcp-duid":          kindString,
+		"dhcp-timeout":       kindInt32,
+		"dhcp-send-hostname": kindBool,
+		"dhcp-hostname":      kindString,
+		"never-default":      kindBool,
+		"may-fail":           kindBool,
+		"ip6-privacy":        kindInt32,
+		"addr-gen-mode":      kindInt32,
+		"token":              kindString,
+	},
+	SettingVPN: {
+		"service-type": kindString,
+		"user-name":    kindString,
+		"persistent":   kindBool,
+		"data":         kindStringMap,
+		"secrets":      kindStringMap,
+		"timeout":      kindUint32,
+	},
+	SettingWireGuard: {
+		"private-key":            kindString,
+		"private-key-flags":      kindUint32,
+		"listen-port":            kindUint32,
+		"fwmark":                 kindUint32,
+		"peer-routes":            kindBool,
+		"mtu":                    kindUint32,
+		"ip4-auto-default-route": kindInt32,
+		"ip6-auto-default-route": kindInt32,
+		"peers":                  kindMaps,
+	},
+	SettingBond: {
+		"options": kindStringMap,
+	},
+	SettingBridge: {
+		"mac-address":        kindMAC,
+		"stp":                kindBool,
+		"priority":           kindUint32,
+		"forward-delay":      kindUint32,
+		"hello-time":         kindUint32,
+		"max-age":            kindUint32,
+		"ageing-time":        kindUint32,
+		"multicast-snooping": kindBool,
+		"vlan-filtering":     kindBool,
+		"vlan-default-pvid":  kindUint32,
+	},
+	SettingBridgePort: {
+		"priority":     kindUint32,
+		"path-cost":    kindUint32,
+		"hairpin-mode": kindBool,
+	},
+	SettingVLAN: {
+		"parent":               kindString,
+		"id":                   kindUint32,
+		"flags":                kindUint32,
+		"ingress-priority-map": kindStrings,
+		"egress-priority-map":  kindStrings,
+	},
+	SettingTeam: {
+		"config": kindString,
+	},
+	SettingTeamPort: {
+		"config": kindString,
+	},
+	SettingGSM: {
+		"apn":             kindString,
+		"number":          kindString,
+		"username":        kindString,
+		"password":        kindString,
+		"password-flags":  kindUint32,
+		"pin":             kindString,
+		"pin-flags":       kindUint32,
+		"network-id":      kindString,
+		"home-only":       kindBool,
+		"auto-config":     kindBool,
+		"device-id":       kindString,
+		"sim-id":          kindString,
+		"sim-operator-id": kindString,
+		"mtu":             kindUint32,
+	},
+	SettingCDMA: {
+		"number":         kindString,
+		"username":       kindString,
+		"password":       kindString,
+		"password-flags": kindUint32,
+		"mtu":            kindUint32,
+	},
+	SettingBluetooth: {
+		"bdaddr": kindMAC,
+		"type":   kindString,
+	},
+	SettingWifiP2P: {
+		"peer":       kindString,
+		"wps-method": kindUint32,
+		"wfd-ies":    kindBytes,
+	},
+}
+
+// nestedSettingsSchema lists the keys of the elements of aa{sv} setting keys.
+var nestedSettingsSchema = map[string]map[string]settingKind{
+	SettingWireGuard + ".peers": {
+		"public-key":           kindString,
+		"preshared-key":        kindString,
+		"preshared-key-flags":  kindUint32,
+		"endpoint":             kindString,
+		"allowed-ips":          kindStrings,
+		"persistent-keepalive": kindUint32,
+	},
+}
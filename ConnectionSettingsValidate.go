@@ -0,0 +1,341 @@
+package gonetworkmanager
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidationError describes a single problem found in a ConnectionSettings
+// map. Path is the offending "setting.key" (or just "setting").
+type ValidationError struct {
+	Path    string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Path + ": " + e.Message
+}
+
+// ValidationErrors is the list of problems returned by Validate.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return "invalid connection settings: " + strings.Join(msgs, "; ")
+}
+
+var (
+	pinRegexp       = regexp.MustCompile(`^[0-9]{4,8}$`)
+	networkIDRegexp = regexp.MustCompile(`^[0-9]{5,6}$`)
+)
+
+var uuidRegexp = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// virtualConnectionTypes lists the connection types that create a software
+// device, which requires connection.interface-name.
+var virtualConnectionTypes = []string{SettingBond, SettingBridge, SettingTeam, SettingWireGuard}
+
+// bondModes lists the values of the bond "mode" option, by name and number.
+var bondModes = []string{
+	"balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb",
+	"0", "1", "2", "3", "4", "5", "6",
+}
+
+var validIPMethods = map[string][]string{
+	SettingIP4Config: {"auto", "link-local", "manual", "shared", "disabled"},
+	SettingIP6Config: {"auto", "dhcp", "link-local", "manual", "shared", "ignore", "disabled"},
+}
+
+// Validate checks a complete connection profile before it is sent to
+// NetworkManager with Settings.AddConnection or Connection.Update, which do
+// not validate on their own. It checks required keys, that the values of
+// known keys convert to their D-Bus types (see EncodeSettings), Wi-Fi SSID
+// and PSK lengths, mutually exclusive options and dependencies between
+// setting groups. Setting groups that NetworkManager adds itself, such as an
+// empty 802-3-ethernet group, are not required. The returned list is empty if
+// no problem was found.
+func Validate(settings ConnectionSettings) ValidationErrors {
+	return validate(settings, true)
+}
+
+// ValidatePartial is like Validate, for the partial profiles passed to
+// NetworkManager.AddAndActivateWirelessConnection. Keys that NetworkManager
+// fills in itself, such as connection.uuid, are not required.
+func ValidatePartial(settings ConnectionSettings) ValidationErrors {
+	return validate(settings, false)
+}
+
+// validate runs the checks of Validate. When complete is false the settings
+// are a partial profile, as passed to AddAndActivateConnection, and keys that
+// NetworkManager fills in itself are not required.
+func validate(settings ConnectionSettings, complete bool) ValidationErrors {
+	settings, errs := normalizeSettings(settings)
+	add := func(path, format string, args ...interface{}) {
+		errs = append(errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	con := settings[SettingConnection]
+	if complete {
+		for _, key := range []string{"id", "uuid", "type"} {
+			if s, _ := con[key].(string); s == "" {
+				add(SettingConnection+"."+key, "required")
+			}
+		}
+		if t, _ := con["type"].(string); t != "" {
+			if iface, _ := con["interface-name"].(string); iface == "" && contains(virtualConnectionTypes, t) {
+				add(SettingConnection+".interface-name", "required by connection.type %q", t)
+			}
+		}
+	}
+	if u, ok := con["uuid"].(string); ok && u != "" && !uuidRegexp.MatchString(u) {
+		add(SettingConnection+".uuid", "%q is not a valid UUID", u)
+	}
+	master, _ := con["master"].(string)
+	slaveType, _ := con["slave-type"].(string)
+	if master != "" && slaveType == "" {
+		add(SettingConnection+".slave-type", "required when connection.master is set")
+	}
+	if slaveType != "" && master == "" {
+		add(SettingConnection+".master", "required when connection.slave-type is set")
+	}
+	if slaveType != "" && !contains([]string{SettingBond, SettingBridge, SettingTeam}, slaveType) {
+		add(SettingConnection+".slave-type", "unknown slave type %q", slaveType)
+	}
+	for _, port := range []string{SettingBridgePort, SettingTeamPort} {
+		if _, ok := settings[port]; ok && slaveType != strings.TrimSuffix(port, "-port") {
+			add(port, "requires connection.slave-type %q", strings.TrimSuffix(port, "-port"))
+		}
+	}
+
+	if bond, ok := settings[SettingBond]; ok {
+		options, _ := bond["options"].(map[string]string)
+		if mode, ok := options["mode"]; ok && !contains(bondModes, mode) {
+			add(SettingBond+".options", "unknown bond mode %q", mode)
+		}
+	}
+
+	if vlan, ok := settings[SettingVLAN]; ok {
+		if parent, _ := vlan["parent"].(string); parent == "" && complete {
+			if mac, _ := settings[SettingWired]["mac-address"].([]byte); len(mac) == 0 {
+				add(SettingVLAN+".parent", "required unless 802-3-ethernet.mac-address identifies the parent")
+			}
+		}
+		if id, ok := vlan["id"].(uint32); ok && id > 4094 {
+			add(SettingVLAN+".id", "must be 0 to 4094, got %d", id)
+		}
+	}
+
+	if wifi, ok := settings[SettingWireless]; ok {
+		ssid, isBytes := wifi["ssid"].([]byte)
+		if wifi["ssid"] == nil {
+			if complete {
+				add(SettingWireless+".ssid", "required")
+			}
+		} else if isBytes && (len(ssid) == 0 || len(ssid) > 32) {
+			add(SettingWireless+".ssid", "must be 1 to 32 bytes long, got %d", len(ssid))
+		}
+		if mode, ok := wifi["mode"].(string); ok && !contains([]string{"infrastructure", "adhoc", "ap", "mesh"}, mode) {
+			add(SettingWireless+".mode", "unknown mode %q", mode)
+		}
+		if band, ok := wifi["band"].(string); ok && band != "a" && band != "bg" {
+			add(SettingWireless+".band", "must be \"a\" or \"bg\", got %q", band)
+		}
+		if _, ok := wifi["channel"]; ok {
+			if _, ok := wifi["band"]; !ok {
+				add(SettingWireless+".channel", "requires 802-11-wireless.band")
+			}
+		}
+		for _, key := range []string{"mac-address", "cloned-mac-address", "bssid"} {
+			if mac, ok := wifi[key].([]byte); ok && len(mac) != 6 {
+				add(SettingWireless+"."+key, "must be 6 bytes long, got %d", len(mac))
+			}
+		}
+		if sec, ok := wifi["security"].(string); ok && sec != "" && settings[sec] == nil {
+			add(SettingWireless+".security", "refers to missing setting %q", sec)
+		}
+	}
+
+	if wired, ok := settings[SettingWired]; ok {
+		for _, key := range []string{"mac-address", "cloned-mac-address"} {
+			if mac, ok := wired[key].([]byte); ok && len(mac) != 6 {
+				add(SettingWired+"."+key, "must be 6 bytes long, got %d", len(mac))
+			}
+		}
+	}
+
+	if sec, ok := settings[SettingWirelessSecurity]; ok {
+		if _, ok := settings[SettingWireless]; !ok {
+			add(SettingWirelessSecurity, "requires setting %q", SettingWireless)
+		}
+		keyMgmt, _ := sec["key-mgmt"].(string)
+		if keyMgmt == "" && complete {
+			add(SettingWirelessSecurity+".key-mgmt", "required")
+		}
+		_, hasWEP := sec["wep-key0"]
+		switch keyMgmt {
+		case "wpa-psk", "sae":
+			if hasWEP {
+				add(SettingWirelessSecurity+".wep-key0", "cannot be used with key-mgmt %q", keyMgmt)
+			}
+			if psk, ok := sec["psk"].(string); ok && !validPSK(psk) {
+				add(SettingWirelessSecurity+".psk", "must be 8 to 63 characters or 64 hexadecimal digits")
+			}
+		case "none", "ieee8021x":
+			if _, ok := sec["psk"]; ok {
+				add(SettingWirelessSecurity+".psk", "cannot be used with key-mgmt %q", keyMgmt)
+			}
+		case "wpa-eap":
+			if _, ok := settings[Setting8021x]; !ok {
+				add(Setting8021x, "required by key-mgmt %q", keyMgmt)
+			}
+		}
+	}
+
+	if wg, ok := settings[SettingWireGuard]; ok {
+		if key, ok := wg["private-key"].(string); ok && !validWireGuardKey(key) {
+			add(SettingWireGuard+".private-key", "must be a base64 encoded 32 byte key")
+		}
+		peers, _ := wg["peers"].([]map[string]interface{})
+		for i, peer := range peers {
+			path := fmt.Sprintf("%s.peers[%d]", SettingWireGuard, i)
+			if key, _ := peer["public-key"].(string); !validWireGuardKey(key) {
+				add(path+".public-key", "must be a base64 encoded 32 byte key")
+			}
+			if key, ok := peer["preshared-key"].(string); ok && !validWireGuardKey(key) {
+				add(path+".preshared-key", "must be a base64 encoded 32 byte key")
+			}
+			if endpoint, ok := peer["endpoint"].(string); ok {
+				if _, _, err := net.SplitHostPort(endpoint); err != nil {
+					add(path+".endpoint", "must be host:port, got %q", endpoint)
+				}
+			}
+			allowed, _ := peer["allowed-ips"].([]string)
+			for _, cidr := range allowed {
+				if _, err := parseIPEntry(cidr); err != nil {
+					add(path+".allowed-ips", "%v", err)
+				}
+			}
+		}
+	}
+
+	if bt, ok := settings[SettingBluetooth]; ok {
+		if addr, ok := bt["bdaddr"].([]byte); ok && len(addr) != 6 {
+			add(SettingBluetooth+".bdaddr", "must be 6 bytes long, got %d", len(addr))
+		} else if bt["bdaddr"] == nil && complete {
+			add(SettingBluetooth+".bdaddr", "required")
+		}
+		switch t, _ := bt["type"].(string); t {
+		case "panu", "nap":
+		case "dun":
+			if settings[SettingGSM] == nil && settings[SettingCDMA] == nil {
+				add(SettingBluetooth+".type", "\"dun\" requires setting %q or %q", SettingGSM, SettingCDMA)
+			}
+		case "":
+			if complete {
+				add(SettingBluetooth+".type", "required")
+			}
+		default:
+			add(SettingBluetooth+".type", "must be \"panu\", \"dun\" or \"nap\", got %q", t)
+		}
+	}
+
+	if gsm, ok := settings[SettingGSM]; ok {
+		if pin, ok := gsm["pin"].(string); ok && !pinRegexp.MatchString(pin) {
+			add(SettingGSM+".pin", "must be 4 to 8 digits")
+		}
+		if id, ok := gsm["network-id"].(string); ok && !networkIDRegexp.MatchString(id) {
+			add(SettingGSM+".network-id", "must be a 5 or 6 digit MCC/MNC, got %q", id)
+		}
+	}
+
+	for _, name := range []string{SettingIP4Config, SettingIP6Config} {
+		ip, ok := settings[name]
+		if !ok {
+			continue
+		}
+		method, _ := ip["method"].(string)
+		if method != "" && !contains(validIPMethods[name], method) {
+			add(name+".method", "unknown method %q", method)
+		}
+		hasAddresses := !isEmptyValue(ip["addresses"]) || !isEmptyValue(ip["address-data"])
+		switch method {
+		case "manual":
+			if !hasAddresses {
+				add(name+".addresses", "required when method is \"manual\"")
+			}
+		case "link-local", "disabled", "ignore":
+			if hasAddresses {
+				add(name+".addresses", "cannot be set when method is %q", method)
+			}
+		}
+		if addrs, ok := ip["addresses"].([][]uint32); ok {
+			for i, a := range addrs {
+				if len(a) != 3 {
+					add(fmt.Sprintf("%s.addresses[%d]", name, i), "must be an (address, prefix, gateway) tuple")
+				} else if a[1] > 32 {
+					add(fmt.Sprintf("%s.addresses[%d]", name, i), "invalid prefix %d", a[1])
+				}
+			}
+		}
+	}
+
+	return errs
+}
+
+// validPSK reports whether psk is a valid WPA passphrase or raw hex key.
+func validPSK(psk string) bool {
+	if len(psk) == 64 {
+		for _, c := range psk {
+			if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+				return false
+			}
+		}
+		return true
+	}
+	return len(psk) >= 8 && len(psk) <= 63
+}
+
+func isEmptyValue(v interface{}) bool {
+	rv := reflect.ValueOf(v)
+	switch {
+	case !rv.IsValid():
+		return true
+	case rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map:
+		return rv.Len() == 0
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedSettingNames(settings ConnectionSettings) []string {
+	names := make([]string, 0, len(settings))
+	for name := range settings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
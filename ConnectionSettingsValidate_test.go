@@ -0,0 +1,206 @@
+package gonetworkmanager
+
+import (
+	"strings"
+	"testing"
+)
+
+const testUUID = "4b2a64c5-1d6b-4c4e-9b5e-6f0d7a1e2c3d"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings ConnectionSettings
+		errs     []string
+	}{
+		{
+			name: "ethernet without 802-3-ethernet group",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "wired", "uuid": testUUID, "type": SettingWired},
+			},
+		},
+		{
+			name: "missing required keys",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "wired"},
+			},
+			errs: []string{"connection.uuid", "connection.type"},
+		},
+		{
+			name: "invalid uuid",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "wired", "uuid": "not-a-uuid", "type": SettingWired},
+			},
+			errs: []string{"connection.uuid"},
+		},
+		{
+			name: "wpa-psk",
+			settings: ConnectionSettings{
+				SettingConnection:       {"id": "wifi", "uuid": testUUID, "type": SettingWireless},
+				SettingWireless:         {"ssid": "home", "security": SettingWirelessSecurity},
+				SettingWirelessSecurity: {"key-mgmt": "wpa-psk", "psk": "secret123"},
+			},
+		},
+		{
+			name: "short psk and long ssid",
+			settings: ConnectionSettings{
+				SettingConnection:       {"id": "wifi", "uuid": testUUID, "type": SettingWireless},
+				SettingWireless:         {"ssid": strings.Repeat("x", 33)},
+				SettingWirelessSecurity: {"key-mgmt": "wpa-psk", "psk": "short"},
+			},
+			errs: []string{"802-11-wireless.ssid", "802-11-wireless-security.psk"},
+		},
+		{
+			name: "missing security setting",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "wifi", "uuid": testUUID, "type": SettingWireless},
+				SettingWireless:   {"ssid": "home", "security": SettingWirelessSecurity},
+			},
+			errs: []string{"802-11-wireless.security"},
+		},
+		{
+			name: "manual without addresses",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "wired", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "manual"},
+			},
+			errs: []string{"ipv4.addresses"},
+		},
+		{
+			name: "manual with address-data",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "wired", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "manual", "address-data": []string{"192.168.1.2/24"}},
+			},
+		},
+		{
+			name: "bond without interface name and unknown mode",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "bond", "uuid": testUUID, "type": SettingBond},
+				SettingBond:       {"options": map[string]string{"mode": "fastest"}},
+			},
+			errs: []string{"connection.interface-name", "bond.options"},
+		},
+		{
+			name: "slave type without master",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "port", "uuid": testUUID, "type": SettingWired, "slave-type": SettingBond},
+			},
+			errs: []string{"connection.master"},
+		},
+		{
+			name: "vlan id out of range",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "vlan", "uuid": testUUID, "type": SettingVLAN},
+				SettingVLAN:       {"parent": "eth0", "id": uint32(4095)},
+			},
+			errs: []string{"vlan.id"},
+		},
+		{
+			name: "wireguard with invalid keys",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "wg", "uuid": testUUID, "type": SettingWireGuard, "interface-name": "wg0"},
+				SettingWireGuard: {
+					"private-key": "short",
+					"peers": []map[string]interface{}{
+						{"public-key": "short", "endpoint": "no-port"},
+					},
+				},
+			},
+			errs: []string{"wireguard.private-key", "wireguard.peers[0].public-key", "wireguard.peers[0].endpoint"},
+		},
+		{
+			name: "bluetooth dun without modem setting",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "bt", "uuid": testUUID, "type": SettingBluetooth},
+				SettingBluetooth:  {"bdaddr": []byte{1, 2, 3, 4, 5, 6}, "type": "dun"},
+			},
+			errs: []string{"bluetooth.type"},
+		},
+		{
+			name: "bluetooth without type",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "bt", "uuid": testUUID, "type": SettingBluetooth},
+				SettingBluetooth:  {"bdaddr": []byte{1, 2, 3, 4, 5, 6}},
+			},
+			errs: []string{"bluetooth.type"},
+		},
+		{
+			name: "manual with empty address list",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "wired", "uuid": testUUID, "type": SettingWired},
+				SettingIP4Config:  {"method": "manual", "addresses": []string{}, "address-data": []string{}},
+			},
+			errs: []string{"ipv4.addresses"},
+		},
+		{
+			name: "gsm pin",
+			settings: ConnectionSettings{
+				SettingConnection: {"id": "gsm", "uuid": testUUID, "type": SettingGSM},
+				SettingGSM:        {"pin": "12"},
+			},
+			errs: []string{"gsm.pin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Validate(tt.settings)
+			var paths []string
+			for _, err := range errs {
+				paths = append(paths, err.Path)
+			}
+			if strings.Join(paths, " ") != strings.Join(tt.errs, " ") {
+				t.Errorf("got errors %v, want errors on %v", errs, tt.errs)
+			}
+		})
+	}
+}
+
+func TestValidatePartial(t *testing.T) {
+	settings := ConnectionSettings{
+		SettingWireless:         {"ssid": "home", "security": SettingWirelessSecurity},
+		SettingWirelessSecurity: {"psk": "secret123"},
+	}
+	if errs := ValidatePartial(settings); len(errs) != 0 {
+		t.Errorf("ValidatePartial: unexpected errors %v", errs)
+	}
+	if errs := Validate(settings); len(errs) == 0 {
+		t.Error("Validate: expected errors for a partial profile")
+	}
+}
+
+func TestValidatePartialBluetooth(t *testing.T) {
+	settings := ConnectionSettings{
+		SettingBluetooth: {"bdaddr": []byte{1, 2, 3, 4, 5, 6}},
+	}
+	if errs := ValidatePartial(settings); len(errs) != 0 {
+		t.Errorf("ValidatePartial: unexpected errors %v", errs)
+	}
+	settings[SettingBluetooth]["type"] = "modem"
+	if errs := ValidatePartial(settings); len(errs) != 1 || errs[0].Path != "bluetooth.type" {
+		t.Errorf("ValidatePartial: got %v, want an error for bluetooth.type", errs)
+	}
+}
+
+func TestIsEmptyValue(t *testing.T) {
+	tests := []struct {
+		v     interface{}
+		empty bool
+	}{
+		{nil, true},
+		{[]string{}, true},
+		{[]byte{}, true},
+		{[][]uint32{}, true},
+		{[]map[string]interface{}{}, true},
+		{map[string]string{}, true},
+		{[]string{"10.0.0.1/8"}, false},
+		{"", false},
+		{uint32(0), false},
+	}
+	for _, tt := range tests {
+		if got := isEmptyValue(tt.v); got != tt.empty {
+			t.Errorf("isEmptyValue(%#v) = %v, want %v", tt.v, got, tt.empty)
+		}
+	}
+}
@@ -12,12 +12,17 @@ const (
 	DHCP4ConfigPropertyOptions = DHCP4ConfigInterface + ".Options"
 )
 
-type DHCP4Options map[string]interface{}
-
 type DHCP4Config interface {
 	// GetOptions gets options map of configuration returned by the IPv4 DHCP server.
 	GetOptions() (DHCP4Options, error)
 
+	// SubscribeOptions returns a channel receiving the options each time
+	// they change, as when the lease is renewed.
+	SubscribeOptions() <-chan DHCP4Options
+
+	// UnsubscribeOptions stops the subscription and closes its channel.
+	UnsubscribeOptions()
+
 	MarshalJSON() ([]byte, error)
 }
 
@@ -28,6 +33,9 @@ func NewDHCP4Config(objectPath dbus.ObjectPath) (DHCP4Config, error) {
 
 type dhcp4Config struct {
 	dbusBase
+
+	optionsSub  *signalSubscription
+	optionsChan chan DHCP4Options
 }
 
 func (c *dhcp4Config) GetOptions() (DHCP4Options, error) {
@@ -35,13 +43,61 @@ func (c *dhcp4Config) GetOptions() (DHCP4Options, error) {
 	if err != nil {
 		return nil, err
 	}
+	return makeDHCP4Options(options), nil
+}
+
+func makeDHCP4Options(options map[string]dbus.Variant) DHCP4Options {
 	rv := make(DHCP4Options)
 
 	for k, v := range options {
 		rv[k] = v.Value()
 	}
 
-	return rv, nil
+	return rv
+}
+
+func (c *dhcp4Config) SubscribeOptions() <-chan DHCP4Options {
+	if c.optionsChan != nil {
+		return c.optionsChan
+	}
+
+	sub := c.subscribeSignals(dbusPropertiesInterface, dbusSignalPropertiesChanged)
+	optionsChan := make(chan DHCP4Options, 10)
+	c.optionsSub, c.optionsChan = sub, optionsChan
+
+	go func() {
+		defer close(optionsChan)
+		for {
+			sig, ok := sub.next()
+			if !ok {
+				return
+			}
+			changed, ok := changedProperties(sig, DHCP4ConfigInterface)
+			if !ok {
+				continue
+			}
+			options, ok := changed["Options"].Value().(map[string]dbus.Variant)
+			if !ok {
+				continue
+			}
+			select {
+			case optionsChan <- makeDHCP4Options(options):
+			case <-sub.closed():
+				return
+			}
+		}
+	}()
+
+	return optionsChan
+}
+
+func (c *dhcp4Config) UnsubscribeOptions() {
+	if c.optionsSub == nil {
+		return
+	}
+	c.optionsSub.close()
+	c.optionsSub = nil
+	c.optionsChan = nil
 }
 
 func (c *dhcp4Config) MarshalJSON() ([]byte, error) {
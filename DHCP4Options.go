@@ -0,0 +1,214 @@
+package gonetworkmanager
+
+import (
+	"encoding/hex"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DHCP4Options are the options of a DHCP lease, as strings keyed by the
+// dhclient option names, such as "routers" or "dhcp_lease_time". The typed
+// accessors return zero values for options the server did not send or that
+// cannot be parsed.
+type DHCP4Options map[string]interface{}
+
+// DHCP4StaticRoute is a route of the classful static routes option.
+type DHCP4StaticRoute struct {
+	Destination net.IP
+	Prefix      int
+	Router      net.IP
+}
+
+func (o DHCP4Options) get(key string) string {
+	s, _ := o[key].(string)
+	return strings.TrimSpace(s)
+}
+
+func (o DHCP4Options) getIP(key string) net.IP {
+	return net.ParseIP(o.get(key)).To4()
+}
+
+func (o DHCP4Options) getIPs(key string) []net.IP {
+	var ips []net.IP
+	for _, f := range strings.Fields(o.get(key)) {
+		if ip := net.ParseIP(f).To4(); ip != nil {
+			ips = append(ips, ip)
+		}
+	}
+	return ips
+}
+
+func (o DHCP4Options) getSeconds(key string) time.Duration {
+	n, err := strconv.ParseUint(o.get(key), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return time.Duration(n) * time.Second
+}
+
+// Routers returns the routers, in order of preference.
+func (o DHCP4Options) Routers() []net.IP {
+	return o.getIPs("routers")
+}
+
+// Router returns the preferred router.
+func (o DHCP4Options) Router() net.IP {
+	if routers := o.Routers(); len(routers) > 0 {
+		return routers[0]
+	}
+	return nil
+}
+
+func (o DHCP4Options) SubnetMask() net.IPMask {
+	mask := o.getIP("subnet_mask")
+	if mask == nil {
+		return nil
+	}
+	return net.IPMask(mask)
+}
+
+func (o DHCP4Options) DomainNameServers() []net.IP {
+	return o.getIPs("domain_name_servers")
+}
+
+func (o DHCP4Options) DomainName() string {
+	return o.get("domain_name")
+}
+
+func (o DHCP4Options) NTPServers() []net.IP {
+	return o.getIPs("ntp_servers")
+}
+
+// ServerIdentifier returns the address of the DHCP server.
+func (o DHCP4Options) ServerIdentifier() net.IP {
+	return o.getIP("dhcp_server_identifier")
+}
+
+// ClassfulStaticRoutes returns the routes of the static routes option, which
+// carries destination and router pairs. dhclient gives the destination as a
+// plain address whose prefix follows from its class, NetworkManager's
+// internal client as "address/prefix".
+func (o DHCP4Options) ClassfulStaticRoutes() []DHCP4StaticRoute {
+	fields := strings.Fields(o.get("static_routes"))
+	var routes []DHCP4StaticRoute
+	for i := 0; i+1 < len(fields); i += 2 {
+		var route DHCP4StaticRoute
+		if _, dest, err := net.ParseCIDR(fields[i]); err == nil {
+			route.Destination = dest.IP.To4()
+			route.Prefix, _ = dest.Mask.Size()
+		} else if dest := net.ParseIP(fields[i]).To4(); dest != nil {
+			route.Destination = dest
+			route.Prefix, _ = dest.DefaultMask().Size()
+		}
+		route.Router = net.ParseIP(fields[i+1]).To4()
+		if route.Destination == nil || route.Router == nil {
+			return nil
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// VendorClassIdentifier returns the vendor class sent by the server.
+func (o DHCP4Options) VendorClassIdentifier() string {
+	return o.get("vendor_class_identifier")
+}
+
+// VendorEncapsulatedOptions returns the raw vendor specific information.
+func (o DHCP4Options) VendorEncapsulatedOptions() []byte {
+	s := strings.Replace(o.get("vendor_encapsulated_options"), ":", "", -1)
+	b, err := hex.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil
+	}
+	return b
+}
+
+// PrivateOptions returns the site specific options 224 to 254, by option
+// code, as reported by NetworkManager's internal DHCP client.
+func (o DHCP4Options) PrivateOptions() map[uint8]string {
+	rv := make(map[uint8]string)
+	for k := range o {
+		if !strings.HasPrefix(k, "private_") {
+			continue
+		}
+		code, err := strconv.ParseUint(strings.TrimPrefix(k, "private_"), 10, 8)
+		if err != nil {
+			continue
+		}
+		rv[uint8(code)] = o.get(k)
+	}
+	return rv
+}
+
+// LeaseTime returns the duration of the lease.
+func (o DHCP4Options) LeaseTime() time.Duration {
+	return o.getSeconds("dhcp_lease_time")
+}
+
+// Expiry returns the time the lease expires.
+func (o DHCP4Options) Expiry() time.Time {
+	n, err := strconv.ParseInt(o.get("expiry"), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(n, 0)
+}
+
+// obtained returns the time the lease was obtained or last renewed.
+func (o DHCP4Options) obtained() time.Time {
+	expiry := o.Expiry()
+	if expiry.IsZero() {
+		return expiry
+	}
+	return expiry.Add(-o.LeaseTime())
+}
+
+// Renewal returns the time the client starts renewing the lease with the
+// server that granted it. Servers that do not send the renewal time get the
+// default of half the lease time.
+func (o DHCP4Options) Renewal() time.Time {
+	obtained := o.obtained()
+	if obtained.IsZero() {
+		return obtained
+	}
+	if t1 := o.getSeconds("dhcp_renewal_time"); t1 > 0 {
+		return obtained.Add(t1)
+	}
+	return obtained.Add(o.LeaseTime() / 2)
+}
+
+// Rebinding returns the time the client starts renewing the lease with any
+// server. The default is seven eighths of the lease time.
+func (o DHCP4Options) Rebinding() time.Time {
+	obtained := o.obtained()
+	if obtained.IsZero() {
+		return obtained
+	}
+	if t2 := o.getSeconds("dhcp_rebinding_time"); t2 > 0 {
+		return obtained.Add(t2)
+	}
+	return obtained.Add(o.LeaseTime() * 7 / 8)
+}
+
+// TimeUntilRenewal returns the time left until Renewal, 0 once it passed.
+func (o DHCP4Options) TimeUntilRenewal() time.Duration {
+	return timeUntil(o.Renewal())
+}
+
+// TimeUntilExpiry returns the time left until Expiry, 0 once it passed.
+func (o DHCP4Options) TimeUntilExpiry() time.Duration {
+	return timeUntil(o.Expiry())
+}
+
+func timeUntil(t time.Time) time.Duration {
+	if t.IsZero() {
+		return 0
+	}
+	if d := time.Until(t); d > 0 {
+		return d
+	}
+	return 0
+}
@@ -0,0 +1,142 @@
+package gonetworkmanager
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDHCP4Options(t *testing.T) {
+	o := DHCP4Options{
+		"routers":                     "192.168.1.1 192.168.1.2",
+		"subnet_mask":                 "255.255.255.0",
+		"domain_name_servers":         "8.8.8.8 bogus 1.1.1.1",
+		"domain_name":                 " example.com ",
+		"ntp_servers":                 "",
+		"dhcp_server_identifier":      "192.168.1.1",
+		"vendor_encapsulated_options": "01:04:0a:00:00:01",
+		"private_224":                 "hello",
+		"private_bogus":               "ignored",
+	}
+
+	tests := []struct {
+		name      string
+		got, want interface{}
+	}{
+		{"Routers", o.Routers(), []net.IP{net.IPv4(192, 168, 1, 1).To4(), net.IPv4(192, 168, 1, 2).To4()}},
+		{"Router", o.Router(), net.IPv4(192, 168, 1, 1).To4()},
+		{"SubnetMask", o.SubnetMask(), net.CIDRMask(24, 32)},
+		{"DomainNameServers", o.DomainNameServers(), []net.IP{net.IPv4(8, 8, 8, 8).To4(), net.IPv4(1, 1, 1, 1).To4()}},
+		{"DomainName", o.DomainName(), "example.com"},
+		{"NTPServers", o.NTPServers(), []net.IP(nil)},
+		{"ServerIdentifier", o.ServerIdentifier(), net.IPv4(192, 168, 1, 1).To4()},
+		{"VendorEncapsulatedOptions", o.VendorEncapsulatedOptions(), []byte{1, 4, 10, 0, 0, 1}},
+		{"PrivateOptions", o.PrivateOptions(), map[uint8]string{224: "hello"}},
+		{"missing Router", DHCP4Options{}.Router(), net.IP(nil)},
+		{"missing SubnetMask", DHCP4Options{}.SubnetMask(), net.IPMask(nil)},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s = %v, want %v", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestDHCP4OptionsClassfulStaticRoutes(t *testing.T) {
+	route := func(dest string, prefix int, router string) DHCP4StaticRoute {
+		return DHCP4StaticRoute{Destination: net.ParseIP(dest).To4(), Prefix: prefix, Router: net.ParseIP(router).To4()}
+	}
+
+	tests := []struct {
+		name, value string
+		want        []DHCP4StaticRoute
+	}{
+		{"dhclient", "10.0.0.0 192.168.1.1 172.16.0.0 192.168.1.2", []DHCP4StaticRoute{
+			route("10.0.0.0", 8, "192.168.1.1"),
+			route("172.16.0.0", 16, "192.168.1.2"),
+		}},
+		{"internal client", "10.0.0.0/8 192.168.1.1 192.168.5.0/24 192.168.1.2", []DHCP4StaticRoute{
+			route("10.0.0.0", 8, "192.168.1.1"),
+			route("192.168.5.0", 24, "192.168.1.2"),
+		}},
+		{"host route", "10.1.2.3/32 192.168.1.1", []DHCP4StaticRoute{
+			route("10.1.2.3", 32, "192.168.1.1"),
+		}},
+		{"invalid destination", "10.0.0.0/40 192.168.1.1", nil},
+		{"invalid router", "10.0.0.0 router", nil},
+		{"missing", "", nil},
+	}
+	for _, tt := range tests {
+		got := DHCP4Options{"static_routes": tt.value}.ClassfulStaticRoutes()
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDHCP4OptionsLeaseTiming(t *testing.T) {
+	expiry := time.Unix(1600003600, 0)
+
+	tests := []struct {
+		name                       string
+		options                    DHCP4Options
+		lease                      time.Duration
+		expiry, renewal, rebinding time.Time
+	}{
+		{
+			name:      "defaults",
+			options:   DHCP4Options{"dhcp_lease_time": "3600", "expiry": "1600003600"},
+			lease:     time.Hour,
+			expiry:    expiry,
+			renewal:   expiry.Add(-30 * time.Minute),
+			rebinding: expiry.Add(-450 * time.Second),
+		},
+		{
+			name: "times sent by the server",
+			options: DHCP4Options{
+				"dhcp_lease_time":     "3600",
+				"dhcp_renewal_time":   "600",
+				"dhcp_rebinding_time": "1200",
+				"expiry":              "1600003600",
+			},
+			lease:     time.Hour,
+			expiry:    expiry,
+			renewal:   expiry.Add(-50 * time.Minute),
+			rebinding: expiry.Add(-40 * time.Minute),
+		},
+		{
+			name:    "no expiry",
+			options: DHCP4Options{"dhcp_lease_time": "3600"},
+			lease:   time.Hour,
+		},
+		{
+			name:    "invalid",
+			options: DHCP4Options{"dhcp_lease_time": "-1", "expiry": "soon"},
+		},
+	}
+	for _, tt := range tests {
+		o := tt.options
+		if got := o.LeaseTime(); got != tt.lease {
+			t.Errorf("%s: LeaseTime = %v, want %v", tt.name, got, tt.lease)
+		}
+		if got := o.Expiry(); !got.Equal(tt.expiry) {
+			t.Errorf("%s: Expiry = %v, want %v", tt.name, got, tt.expiry)
+		}
+		if got := o.Renewal(); !got.Equal(tt.renewal) {
+			t.Errorf("%s: Renewal = %v, want %v", tt.name, got, tt.renewal)
+		}
+		if got := o.Rebinding(); !got.Equal(tt.rebinding) {
+			t.Errorf("%s: Rebinding = %v, want %v", tt.name, got, tt.rebinding)
+		}
+		// All of them are in the past.
+		if o.TimeUntilRenewal() != 0 || o.TimeUntilExpiry() != 0 {
+			t.Errorf("%s: time left on an expired lease", tt.name)
+		}
+	}
+
+	future := DHCP4Options{"dhcp_lease_time": "3600", "expiry": "4102444800"}
+	if future.TimeUntilExpiry() <= 0 || future.TimeUntilRenewal() >= future.TimeUntilExpiry() {
+		t.Errorf("TimeUntilRenewal %v, TimeUntilExpiry %v", future.TimeUntilRenewal(), future.TimeUntilExpiry())
+	}
+}
@@ -17,6 +17,9 @@ const (
 	DevicePropertyDeviceType           = DeviceInterface + ".DeviceType"
 	DevicePropertyAvailableConnections = DeviceInterface + ".AvailableConnections"
 	DevicePropertyDhcp4Config          = DeviceInterface + ".Dhcp4Config"
+	DevicePropertyPorts                = DeviceInterface + ".Ports"
+
+	DeviceGetAppliedConnection = DeviceInterface + ".GetAppliedConnection"
 )
 
 func DeviceFactory(objectPath dbus.ObjectPath) (Device, error) {
@@ -32,6 +35,36 @@ func DeviceFactory(objectPath dbus.ObjectPath) (Device, error) {
 	switch dt {
 	case NmDeviceTypeWifi:
 		return NewWirelessDevice(objectPath)
+	case NmDeviceTypeWireguard:
+		return NewWireGuardDevice(objectPath)
+	case NmDeviceTypeBond:
+		return NewBondDevice(objectPath)
+	case NmDeviceTypeBridge:
+		return NewBridgeDevice(objectPath)
+	case NmDeviceTypeVlan:
+		return NewVLANDevice(objectPath)
+	case NmDeviceTypeTeam:
+		return NewTeamDevice(objectPath)
+	case NmDeviceTypeModem:
+		return NewModemDevice(objectPath)
+	case NmDeviceTypeBt:
+		return NewBluetoothDevice(objectPath)
+	case NmDeviceTypeGeneric:
+		return NewGenericDevice(objectPath)
+	case NmDeviceTypeTun:
+		return NewTunDevice(objectPath)
+	case NmDeviceTypeIpTunnel:
+		return NewIPTunnelDevice(objectPath)
+	case NmDeviceTypeMacvlan:
+		return NewMacvlanDevice(objectPath)
+	case NmDeviceTypeVxlan:
+		return NewVxlanDevice(objectPath)
+	case NmDeviceTypeVeth:
+		return NewVethDevice(objectPath)
+	case NmDeviceTypeVrf:
+		return NewVrfDevice(objectPath)
+	case NmDeviceTypeWifiP2p:
+		return NewWifiP2PDevice(objectPath)
 	}
 
 	return d, nil
@@ -68,6 +101,9 @@ type Device interface {
 	// connection that is currently 'available' through this device.
 	GetAvailableConnections() ([]Connection, error)
 
+	// GetStatistics gets the traffic statistics of the device.
+	GetStatistics() (DeviceStatistics, error)
+
 	MarshalJSON() ([]byte, error)
 }
 
@@ -159,6 +195,43 @@ func (d *device) GetAvailableConnections() ([]Connection, error) {
 	return conns, nil
 }
 
+func (d *device) GetStatistics() (DeviceStatistics, error) {
+	return NewDeviceStatistics(d.GetPath())
+}
+
+// getSlaves returns the devices enslaved to a master device, from its
+// type-specific Slaves property, or from the Ports property of newer
+// NetworkManager versions.
+func (d *device) getSlaves(slavesProperty string) ([]Device, error) {
+	paths, err := d.getSliceObjectProperty(slavesProperty)
+	if err != nil {
+		paths, err = d.getSliceObjectProperty(DevicePropertyPorts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slaves := make([]Device, len(paths))
+	for i, path := range paths {
+		slaves[i], err = DeviceFactory(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return slaves, nil
+}
+
+// getAppliedConnection returns the settings currently applied to the device,
+// which may differ from those of its connection if it was reapplied.
+func (d *device) getAppliedConnection() (ConnectionSettings, error) {
+	var settings map[string]map[string]dbus.Variant
+	var versionID uint64
+	if err := d.call2(&settings, &versionID, DeviceGetAppliedConnection, uint32(0)); err != nil {
+		return nil, err
+	}
+	return DecodeSettings(settings), nil
+}
+
 func (d *device) marshalMap() (map[string]interface{}, error) {
 	Interface, err := d.GetInterface()
 	if err != nil {
@@ -0,0 +1,58 @@
+package gonetworkmanager
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/godbus/dbus"
+)
+
+// fakeBusObject answers property reads from a map.
+type fakeBusObject struct {
+	dbus.BusObject
+	path       dbus.ObjectPath
+	properties map[string]interface{}
+}
+
+func (o *fakeBusObject) Path() dbus.ObjectPath {
+	return o.path
+}
+
+func (o *fakeBusObject) GetProperty(p string) (dbus.Variant, error) {
+	v, ok := o.properties[p]
+	if !ok {
+		return dbus.Variant{}, errors.New("no such property " + p)
+	}
+	return dbus.MakeVariant(v), nil
+}
+
+func fakeDevice(properties map[string]interface{}) device {
+	return device{dbusBase: dbusBase{obj: &fakeBusObject{
+		path:       "/org/freedesktop/NetworkManager/Devices/7",
+		properties: properties,
+	}}}
+}
+
+func TestVLANDeviceWithoutParent(t *testing.T) {
+	d := &vlanDevice{fakeDevice(map[string]interface{}{VLANDevicePropertyParent: dbus.ObjectPath("/")})}
+	parent, err := d.GetParent()
+	if parent != nil || err != nil {
+		t.Errorf("got %v, %v, want nil, nil", parent, err)
+	}
+}
+
+func TestMacvlanDeviceWithoutParent(t *testing.T) {
+	d := &macvlanDevice{fakeDevice(map[string]interface{}{MacvlanDevicePropertyParent: dbus.ObjectPath("/")})}
+	parent, err := d.GetParent()
+	if parent != nil || err != nil {
+		t.Errorf("got %v, %v, want nil, nil", parent, err)
+	}
+}
+
+func TestVethDeviceWithoutPeer(t *testing.T) {
+	d := &vethDevice{fakeDevice(map[string]interface{}{VethDevicePropertyPeer: dbus.ObjectPath("/")})}
+	peer, err := d.GetPeer()
+	if peer != nil || err != nil {
+		t.Errorf("got %v, %v, want nil, nil", peer, err)
+	}
+}
@@ -0,0 +1,163 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	DeviceStatisticsInterface = DeviceInterface + ".Statistics"
+
+	DeviceStatisticsPropertyRefreshRateMs = DeviceStatisticsInterface + ".RefreshRateMs"
+	DeviceStatisticsPropertyTxBytes       = DeviceStatisticsInterface + ".TxBytes"
+	DeviceStatisticsPropertyRxBytes       = DeviceStatisticsInterface + ".RxBytes"
+)
+
+// DeviceCounters are the traffic counters of a device.
+type DeviceCounters struct {
+	TxBytes uint64
+	RxBytes uint64
+}
+
+// DeviceStatistics gives the traffic counters of a device. NetworkManager
+// only updates them while the refresh rate is not 0.
+type DeviceStatistics interface {
+	GetPath() dbus.ObjectPath
+
+	// GetRefreshRateMs gets the refresh rate of the counters in
+	// milliseconds, 0 if they are not updated.
+	GetRefreshRateMs() (uint32, error)
+
+	// SetRefreshRateMs sets the refresh rate of the counters in
+	// milliseconds. 0 stops updating them.
+	SetRefreshRateMs(rate uint32) error
+
+	// GetTxBytes gets the number of bytes transmitted.
+	GetTxBytes() (uint64, error)
+
+	// GetRxBytes gets the number of bytes received.
+	GetRxBytes() (uint64, error)
+
+	// SubscribeCounters returns a channel receiving the counters each time
+	// they are refreshed.
+	SubscribeCounters() <-chan DeviceCounters
+
+	// UnsubscribeCounters stops the subscription and closes its channel.
+	UnsubscribeCounters()
+
+	MarshalJSON() ([]byte, error)
+}
+
+func NewDeviceStatistics(objectPath dbus.ObjectPath) (DeviceStatistics, error) {
+	var s deviceStatistics
+	return &s, s.init(NetworkManagerInterface, objectPath)
+}
+
+type deviceStatistics struct {
+	dbusBase
+
+	countersSub  *signalSubscription
+	countersChan chan DeviceCounters
+}
+
+func (s *deviceStatistics) GetPath() dbus.ObjectPath {
+	return s.obj.Path()
+}
+
+func (s *deviceStatistics) GetRefreshRateMs() (uint32, error) {
+	return s.getUint32Property(DeviceStatisticsPropertyRefreshRateMs)
+}
+
+func (s *deviceStatistics) SetRefreshRateMs(rate uint32) error {
+	return s.setProperty(DeviceStatisticsPropertyRefreshRateMs, rate)
+}
+
+func (s *deviceStatistics) GetTxBytes() (uint64, error) {
+	return s.getUint64Property(DeviceStatisticsPropertyTxBytes)
+}
+
+func (s *deviceStatistics) GetRxBytes() (uint64, error) {
+	return s.getUint64Property(DeviceStatisticsPropertyRxBytes)
+}
+
+func (s *deviceStatistics) getCounters() (DeviceCounters, error) {
+	var c DeviceCounters
+	var err error
+	if c.TxBytes, err = s.GetTxBytes(); err != nil {
+		return c, err
+	}
+	c.RxBytes, err = s.GetRxBytes()
+	return c, err
+}
+
+func (s *deviceStatistics) SubscribeCounters() <-chan DeviceCounters {
+	if s.countersChan != nil {
+		return s.countersChan
+	}
+
+	sub := s.subscribeSignals(dbusPropertiesInterface, dbusSignalPropertiesChanged)
+	countersChan := make(chan DeviceCounters, 10)
+	s.countersSub, s.countersChan = sub, countersChan
+
+	go func() {
+		defer close(countersChan)
+		// Only the counters that changed are signalled, so the others are
+		// carried over from the previous update.
+		counters, _ := s.getCounters()
+		for {
+			sig, ok := sub.next()
+			if !ok {
+				return
+			}
+			changed, ok := changedProperties(sig, DeviceStatisticsInterface)
+			if !ok {
+				continue
+			}
+			tx, txOk := changed["TxBytes"].Value().(uint64)
+			rx, rxOk := changed["RxBytes"].Value().(uint64)
+			if !txOk && !rxOk {
+				continue
+			}
+			if txOk {
+				counters.TxBytes = tx
+			}
+			if rxOk {
+				counters.RxBytes = rx
+			}
+			select {
+			case countersChan <- counters:
+			case <-sub.closed():
+				return
+			}
+		}
+	}()
+
+	return countersChan
+}
+
+func (s *deviceStatistics) UnsubscribeCounters() {
+	if s.countersSub == nil {
+		return
+	}
+	s.countersSub.close()
+	s.countersSub = nil
+	s.countersChan = nil
+}
+
+func (s *deviceStatistics) MarshalJSON() ([]byte, error) {
+	rate, err := s.GetRefreshRateMs()
+	if err != nil {
+		return nil, err
+	}
+	counters, err := s.getCounters()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"RefreshRateMs": rate,
+		"TxBytes":       counters.TxBytes,
+		"RxBytes":       counters.RxBytes,
+	})
+}
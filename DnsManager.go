@@ -0,0 +1,106 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+)
+
+const (
+	DnsManagerInterface  = NetworkManagerInterface + ".DnsManager"
+	DnsManagerObjectPath = NetworkManagerObjectPath + "/DnsManager"
+
+	DnsManagerPropertyMode          = DnsManagerInterface + ".Mode"
+	DnsManagerPropertyRcManager     = DnsManagerInterface + ".RcManager"
+	DnsManagerPropertyConfiguration = DnsManagerInterface + ".Configuration"
+)
+
+// DnsConfiguration is the resolver configuration contributed by one
+// interface.
+type DnsConfiguration struct {
+	Nameservers []string
+
+	// Domains are the search and routing domains the nameservers are used
+	// for.
+	Domains []string
+
+	// Interface is the interface the configuration belongs to, or "" for
+	// the global configuration.
+	Interface string
+
+	// Priority orders the configurations. Those with lower values are used
+	// first.
+	Priority int32
+
+	// Vpn tells whether the configuration belongs to a VPN connection.
+	Vpn bool
+}
+
+type DnsManager interface {
+	// GetMode gets the DNS processing mode, such as "default", "dnsmasq",
+	// "systemd-resolved" or "none".
+	GetMode() (string, error)
+
+	// GetRcManager gets how /etc/resolv.conf is managed, such as "symlink",
+	// "file", "resolvconf" or "unmanaged".
+	GetRcManager() (string, error)
+
+	// GetConfiguration gets the effective resolver configuration, one entry
+	// per interface with nameservers.
+	GetConfiguration() ([]DnsConfiguration, error)
+
+	MarshalJSON() ([]byte, error)
+}
+
+func NewDnsManager() (DnsManager, error) {
+	var d dnsManager
+	return &d, d.init(NetworkManagerInterface, DnsManagerObjectPath)
+}
+
+type dnsManager struct {
+	dbusBase
+}
+
+func (d *dnsManager) GetMode() (string, error) {
+	return d.getStringProperty(DnsManagerPropertyMode)
+}
+
+func (d *dnsManager) GetRcManager() (string, error) {
+	return d.getStringProperty(DnsManagerPropertyRcManager)
+}
+
+func (d *dnsManager) GetConfiguration() ([]DnsConfiguration, error) {
+	entries, err := d.getSliceMapStringVariantProperty(DnsManagerPropertyConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	configs := make([]DnsConfiguration, len(entries))
+	for i, entry := range entries {
+		configs[i].Nameservers, _ = entry["nameservers"].Value().([]string)
+		configs[i].Domains, _ = entry["domains"].Value().([]string)
+		configs[i].Interface, _ = entry["interface"].Value().(string)
+		configs[i].Priority, _ = entry["priority"].Value().(int32)
+		configs[i].Vpn, _ = entry["vpn"].Value().(bool)
+	}
+	return configs, nil
+}
+
+func (d *dnsManager) MarshalJSON() ([]byte, error) {
+	mode, err := d.GetMode()
+	if err != nil {
+		return nil, err
+	}
+	rcManager, err := d.GetRcManager()
+	if err != nil {
+		return nil, err
+	}
+	configuration, err := d.GetConfiguration()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(map[string]interface{}{
+		"Mode":          mode,
+		"RcManager":     rcManager,
+		"Configuration": configuration,
+	})
+}
@@ -0,0 +1,56 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	GenericDeviceInterface = DeviceInterface + ".Generic"
+
+	GenericDevicePropertyHwAddress       = GenericDeviceInterface + ".HwAddress"
+	GenericDevicePropertyTypeDescription = GenericDeviceInterface + ".TypeDescription"
+)
+
+type GenericDevice interface {
+	Device
+
+	// GetHwAddress gets the hardware address of the device.
+	GetHwAddress() (string, error)
+
+	// GetTypeDescription gets a (non-localized) description of the interface
+	// type, such as "gre".
+	GetTypeDescription() (string, error)
+}
+
+func NewGenericDevice(objectPath dbus.ObjectPath) (GenericDevice, error) {
+	var d genericDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type genericDevice struct {
+	device
+}
+
+func (d *genericDevice) GetHwAddress() (string, error) {
+	return d.getStringProperty(GenericDevicePropertyHwAddress)
+}
+
+func (d *genericDevice) GetTypeDescription() (string, error) {
+	return d.getStringProperty(GenericDevicePropertyTypeDescription)
+}
+
+func (d *genericDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["HwAddress"], err = d.GetHwAddress(); err != nil {
+		return nil, err
+	}
+	if m["TypeDescription"], err = d.GetTypeDescription(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,160 @@
+package gonetworkmanager
+
+import (
+	"errors"
+)
+
+// ErrHotspotUnsupported is returned by StartHotspot for devices that cannot
+// run an access point.
+var ErrHotspotUnsupported = errors.New("device does not support access point mode")
+
+// HotspotOptions are the optional radio settings of a hotspot.
+type HotspotOptions struct {
+	// Band is "bg" for 2.4 GHz or "a" for 5 GHz. If empty, NetworkManager
+	// picks one.
+	Band string
+
+	// Channel is the channel to use. It requires Band.
+	Channel uint32
+}
+
+// NewHotspotConnectionSettings returns an access point profile for the
+// wireless device interfaceName, sharing the connectivity of the host with
+// its clients. The network is secured with WPA2 if password is set and open
+// otherwise.
+func NewHotspotConnectionSettings(id, interfaceName, ssid, password string, opts HotspotOptions) (ConnectionSettings, error) {
+	settings := newConnectionSettings(id, SettingWireless)
+	settings[SettingConnection]["interface-name"] = interfaceName
+	settings[SettingConnection]["autoconnect"] = false
+	settings[SettingIP4Config]["method"] = "shared"
+	settings[SettingIP6Config]["method"] = "ignore"
+
+	wifi := map[string]interface{}{
+		"ssid": ssid,
+		"mode": "ap",
+	}
+	if opts.Band != "" {
+		wifi["band"] = opts.Band
+	}
+	if opts.Channel != 0 {
+		wifi["channel"] = opts.Channel
+	}
+	settings[SettingWireless] = wifi
+
+	if password != "" {
+		wifi["security"] = SettingWirelessSecurity
+		settings[SettingWirelessSecurity] = map[string]interface{}{
+			"key-mgmt": "wpa-psk",
+			"proto":    []string{"rsn"},
+			"pairwise": []string{"ccmp"},
+			"group":    []string{"ccmp"},
+			"psk":      password,
+		}
+	}
+
+	return checkedSettings(settings)
+}
+
+// StartHotspot runs a hotspot on device. The profile of a previous hotspot
+// on the same device is updated and reused, otherwise a new one is added.
+func StartHotspot(device WirelessDevice, ssid, password string, opts HotspotOptions) (ActiveConnection, error) {
+	supported, err := device.SupportsAP()
+	if err != nil {
+		return nil, err
+	}
+	if !supported {
+		return nil, ErrHotspotUnsupported
+	}
+
+	iface, err := device.GetInterface()
+	if err != nil {
+		return nil, err
+	}
+	id := hotspotConnectionID(iface)
+	settings, err := NewHotspotConnectionSettings(id, iface, ssid, password, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s, err := NewSettings()
+	if err != nil {
+		return nil, err
+	}
+	connections, err := s.ListConnections()
+	if err != nil {
+		return nil, err
+	}
+
+	var conn Connection
+	for _, c := range connections {
+		current := c.GetSettings()
+		if current[SettingConnection]["id"] == id && isHotspotSettings(current) {
+			conn = c
+			settings[SettingConnection]["uuid"] = current[SettingConnection]["uuid"]
+			break
+		}
+	}
+
+	if conn != nil {
+		err = conn.Update(settings)
+	} else {
+		conn, err = s.AddConnection(settings)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	nm, err := NewNetworkManager()
+	if err != nil {
+		return nil, err
+	}
+	return nm.ActivateConnection(conn, device)
+}
+
+// StopHotspot deactivates the access point connections active on device.
+func StopHotspot(device WirelessDevice) error {
+	nm, err := NewNetworkManager()
+	if err != nil {
+		return err
+	}
+	active, err := nm.GetActiveConnections()
+	if err != nil {
+		return err
+	}
+
+	for _, ac := range active {
+		devices, err := ac.GetDevices()
+		if err != nil {
+			return err
+		}
+		onDevice := false
+		for _, d := range devices {
+			if d.GetPath() == device.GetPath() {
+				onDevice = true
+			}
+		}
+		if !onDevice {
+			continue
+		}
+
+		conn, err := ac.GetConnection()
+		if err != nil {
+			return err
+		}
+		if isHotspotSettings(conn.GetSettings()) {
+			if err := nm.DeactivateConnection(ac); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func hotspotConnectionID(interfaceName string) string {
+	return "Hotspot " + interfaceName
+}
+
+func isHotspotSettings(settings ConnectionSettings) bool {
+	mode, _ := settings[SettingWireless]["mode"].(string)
+	return mode == "ap"
+}
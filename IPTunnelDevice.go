@@ -0,0 +1,125 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	IPTunnelDeviceInterface = DeviceInterface + ".IPTunnel"
+
+	IPTunnelDevicePropertyMode             = IPTunnelDeviceInterface + ".Mode"
+	IPTunnelDevicePropertyParent           = IPTunnelDeviceInterface + ".Parent"
+	IPTunnelDevicePropertyLocal            = IPTunnelDeviceInterface + ".Local"
+	IPTunnelDevicePropertyRemote           = IPTunnelDeviceInterface + ".Remote"
+	IPTunnelDevicePropertyTtl              = IPTunnelDeviceInterface + ".Ttl"
+	IPTunnelDevicePropertyTos              = IPTunnelDeviceInterface + ".Tos"
+	IPTunnelDevicePropertyPathMtuDiscovery = IPTunnelDeviceInterface + ".PathMtuDiscovery"
+	IPTunnelDevicePropertyInputKey         = IPTunnelDeviceInterface + ".InputKey"
+	IPTunnelDevicePropertyOutputKey        = IPTunnelDeviceInterface + ".OutputKey"
+)
+
+type IPTunnelDevice interface {
+	Device
+
+	// GetMode gets the tunneling mode.
+	GetMode() (NmIPTunnelMode, error)
+
+	// GetParent gets the device the tunnel traffic is sent through, or nil
+	// if the tunnel is not bound to a device.
+	GetParent() (Device, error)
+
+	// GetLocal gets the local endpoint of the tunnel.
+	GetLocal() (string, error)
+
+	// GetRemote gets the remote endpoint of the tunnel.
+	GetRemote() (string, error)
+
+	// GetTtl gets the TTL assigned to tunneled packets, 0 to inherit it.
+	GetTtl() (uint8, error)
+
+	// GetTos gets the type of service assigned to tunneled packets.
+	GetTos() (uint8, error)
+
+	// GetPathMtuDiscovery gets whether path MTU discovery is enabled.
+	GetPathMtuDiscovery() (bool, error)
+
+	// GetInputKey gets the key used for incoming packets.
+	GetInputKey() (string, error)
+
+	// GetOutputKey gets the key used for outgoing packets.
+	GetOutputKey() (string, error)
+}
+
+func NewIPTunnelDevice(objectPath dbus.ObjectPath) (IPTunnelDevice, error) {
+	var d ipTunnelDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type ipTunnelDevice struct {
+	device
+}
+
+func (d *ipTunnelDevice) GetMode() (NmIPTunnelMode, error) {
+	v, err := d.getUint32Property(IPTunnelDevicePropertyMode)
+	return NmIPTunnelMode(v), err
+}
+
+func (d *ipTunnelDevice) GetParent() (Device, error) {
+	path, err := d.getObjectProperty(IPTunnelDevicePropertyParent)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+	return DeviceFactory(path)
+}
+
+func (d *ipTunnelDevice) GetLocal() (string, error) {
+	return d.getStringProperty(IPTunnelDevicePropertyLocal)
+}
+
+func (d *ipTunnelDevice) GetRemote() (string, error) {
+	return d.getStringProperty(IPTunnelDevicePropertyRemote)
+}
+
+func (d *ipTunnelDevice) GetTtl() (uint8, error) {
+	return d.getUint8Property(IPTunnelDevicePropertyTtl)
+}
+
+func (d *ipTunnelDevice) GetTos() (uint8, error) {
+	return d.getUint8Property(IPTunnelDevicePropertyTos)
+}
+
+func (d *ipTunnelDevice) GetPathMtuDiscovery() (bool, error) {
+	return d.getBoolProperty(IPTunnelDevicePropertyPathMtuDiscovery)
+}
+
+func (d *ipTunnelDevice) GetInputKey() (string, error) {
+	return d.getStringProperty(IPTunnelDevicePropertyInputKey)
+}
+
+func (d *ipTunnelDevice) GetOutputKey() (string, error) {
+	return d.getStringProperty(IPTunnelDevicePropertyOutputKey)
+}
+
+func (d *ipTunnelDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	mode, err := d.GetMode()
+	if err != nil {
+		return nil, err
+	}
+	m["Mode"] = mode.String()
+	if m["Local"], err = d.GetLocal(); err != nil {
+		return nil, err
+	}
+	if m["Remote"], err = d.GetRemote(); err != nil {
+		return nil, err
+	}
+	if m["Ttl"], err = d.GetTtl(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,777 @@
+package gonetworkmanager
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// keyfileAliases maps setting names to the shorter group names used in
+// keyfiles. The same aliases are used for the connection.type value.
+var keyfileAliases = map[string]string{
+	SettingWired:            "ethernet",
+	SettingWireless:         "wifi",
+	SettingWirelessSecurity: "wifi-security",
+}
+
+// keyfileInlineMaps lists the a{ss} keys whose entries keyfiles store directly
+// in the group of their setting, such as the plugin data of [vpn].
+var keyfileInlineMaps = map[string]string{
+	SettingVPN:  "data",
+	SettingBond: "options",
+}
+
+// keyfileEnumValues lists integer keys that keyfiles may also store by name.
+var keyfileEnumValues = map[string]map[string]int{
+	SettingIP6Config + ".addr-gen-mode": {
+		"eui64":            0,
+		"stable-privacy":   1,
+		"default-or-eui64": 2,
+		"default":          3,
+	},
+}
+
+// keyfileCertKeys lists the "ay" keys holding certificates and private keys,
+// which keyfiles store as a file path or a data URI.
+var keyfileCertKeys = map[string][]string{
+	Setting8021x: {"ca-cert", "client-cert", "private-key", "phase2-ca-cert", "phase2-client-cert", "phase2-private-key"},
+}
+
+// keyfileRouteAttributes gives the D-Bus signature of the route attributes
+// stored in "routeN_options" entries. Other attributes are "u".
+var keyfileRouteAttributes = map[string]string{
+	"src":           "s",
+	"from":          "s",
+	"type":          "s",
+	"tos":           "y",
+	"scope":         "y",
+	"onlink":        "b",
+	"quickack":      "b",
+	"lock-window":   "b",
+	"lock-cwnd":     "b",
+	"lock-initcwnd": "b",
+	"lock-initrwnd": "b",
+	"lock-mtu":      "b",
+	"lock-advmss":   "b",
+}
+
+// keyfileWireGuardPeerPrefix starts the group names of WireGuard peers, which
+// are followed by the public key of the peer.
+const keyfileWireGuardPeerPrefix = "wireguard-peer."
+
+var (
+	keyfileIPEntryRegexp      = regexp.MustCompile(`^(address|addresses|route|routes)(\d*)$`)
+	keyfileRouteOptionsRegexp = regexp.MustCompile(`^routes?(\d*)_options$`)
+)
+
+type keyfileGroup struct {
+	name    string
+	line    int
+	entries []keyfileEntry
+}
+
+type keyfileEntry struct {
+	key   string
+	value string
+	line  int
+}
+
+// ParseKeyfile parses a connection profile in the NetworkManager keyfile
+// format, as stored in .nmconnection files, into ConnectionSettings that can
+// be passed to Settings.AddConnection.
+func ParseKeyfile(data []byte) (ConnectionSettings, error) {
+	groups, err := splitKeyfile(data)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := make(ConnectionSettings)
+	setting := func(name string) map[string]interface{} {
+		if settings[name] == nil {
+			settings[name] = make(map[string]interface{})
+		}
+		return settings[name]
+	}
+
+	for _, g := range groups {
+		if name, key, ok := keyfileMapGroup(g.name); ok {
+			m := make(map[string]string)
+			for _, e := range g.entries {
+				m[e.key] = keyfileUnescape(e.value)
+			}
+			setting(name)[key] = m
+			continue
+		}
+
+		if strings.HasPrefix(g.name, keyfileWireGuardPeerPrefix) {
+			peer, err := parseKeyfileWireGuardPeer(g)
+			if err != nil {
+				return nil, err
+			}
+			wg := setting(SettingWireGuard)
+			peers, _ := wg["peers"].([]map[string]interface{})
+			wg["peers"] = append(peers, peer)
+			continue
+		}
+
+		name := keyfileSettingName(g.name)
+		s := setting(name)
+		var ipEntries map[string][]indexedEntry
+		var routeOptions map[int]keyfileEntry
+
+		for _, e := range g.entries {
+			if name == SettingIP4Config || name == SettingIP6Config {
+				if m := keyfileRouteOptionsRegexp.FindStringSubmatch(e.key); m != nil {
+					if routeOptions == nil {
+						routeOptions = make(map[int]keyfileEntry)
+					}
+					n, _ := strconv.Atoi(m[1])
+					routeOptions[n] = e
+					continue
+				}
+				if m := keyfileIPEntryRegexp.FindStringSubmatch(e.key); m != nil {
+					if ipEntries == nil {
+						ipEntries = make(map[string][]indexedEntry)
+					}
+					kind := "route"
+					if strings.HasPrefix(m[1], "address") {
+						kind = "address"
+					}
+					n, _ := strconv.Atoi(m[2])
+					ipEntries[kind] = append(ipEntries[kind], indexedEntry{n, e.value})
+					continue
+				}
+			}
+
+			kind, known := settingsSchema[name][e.key]
+			if !known {
+				if mapKey, ok := keyfileInlineMaps[name]; ok {
+					data, _ := s[mapKey].(map[string]string)
+					if data == nil {
+						data = make(map[string]string)
+						s[mapKey] = data
+					}
+					data[e.key] = keyfileUnescape(e.value)
+				} else {
+					s[e.key] = keyfileUnescape(e.value)
+				}
+				continue
+			}
+
+			value := e.value
+			if n, ok := keyfileEnumValues[name+"."+e.key][value]; ok {
+				value = strconv.Itoa(n)
+			}
+			var v interface{}
+			var err error
+			if contains(keyfileCertKeys[name], e.key) {
+				v, err = parseKeyfileCert(value)
+			} else {
+				v, err = parseKeyfileValue(kind, value)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("keyfile line %d: %s.%s: %v", e.line, name, e.key, err)
+			}
+			s[e.key] = v
+		}
+
+		for kind, entries := range ipEntries {
+			sort.Slice(entries, func(i, j int) bool { return entries[i].index < entries[j].index })
+			list := make([]string, len(entries))
+			for i, e := range entries {
+				list[i] = e.value
+			}
+			parsed, err := parseIPEntries(list)
+			if err != nil {
+				return nil, fmt.Errorf("keyfile line %d: [%s] %ss: %v", g.line, g.name, kind, err)
+			}
+			if kind == "address" {
+				s["address-data"] = ipData(parsed, false)
+				if _, ok := s["gateway"]; !ok && len(parsed) > 0 && parsed[0].Gateway != nil {
+					s["gateway"] = parsed[0].Gateway.String()
+				}
+			} else {
+				routes := ipData(parsed, true)
+				for i, e := range entries {
+					opt, ok := routeOptions[e.index]
+					if !ok {
+						continue
+					}
+					if err := parseKeyfileRouteOptions(routes[i], opt.value); err != nil {
+						return nil, fmt.Errorf("keyfile line %d: [%s] %s: %v", opt.line, g.name, opt.key, err)
+					}
+					delete(routeOptions, e.index)
+				}
+				s["route-data"] = routes
+			}
+		}
+		for _, opt := range routeOptions {
+			return nil, fmt.Errorf("keyfile line %d: [%s] %s: no matching route", opt.line, g.name, opt.key)
+		}
+	}
+
+	if con, ok := settings[SettingConnection]; ok {
+		if t, ok := con["type"].(string); ok {
+			con["type"] = keyfileSettingName(t)
+		}
+	}
+
+	return settings, nil
+}
+
+type indexedEntry struct {
+	index int
+	value string
+}
+
+// MarshalKeyfile serializes settings, for example as returned by
+// Connection.GetSettings, into the NetworkManager keyfile format.
+func MarshalKeyfile(settings ConnectionSettings) ([]byte, error) {
+	settings, errs := normalizeSettings(settings)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	connType, _ := settings[SettingConnection]["type"].(string)
+	names := sortedSettingNames(settings)
+	sort.SliceStable(names, func(i, j int) bool {
+		return keyfileGroupRank(names[i], connType) < keyfileGroupRank(names[j], connType)
+	})
+
+	var buf bytes.Buffer
+	var mapGroups []string
+	var peerGroups []map[string]interface{}
+
+	for _, name := range names {
+		group := settings[name]
+		fmt.Fprintf(&buf, "[%s]\n", keyfileGroupName(name))
+
+		for _, key := range sortedKeys(group) {
+			value := group[key]
+			kind, known := settingsSchema[name][key]
+
+			switch {
+			case name == SettingConnection && key == "type":
+				fmt.Fprintf(&buf, "type=%s\n", keyfileGroupName(connType))
+			case known && (kind == kindIP4Addresses || kind == kindIP6Addresses ||
+				kind == kindIP4Routes || kind == kindIP6Routes):
+				if _, ok := group[ipDataKeys[key]]; !ok {
+					if err := writeKeyfileIPEntries(&buf, key, value); err != nil {
+						return nil, fmt.Errorf("%s.%s: %v", name, key, err)
+					}
+				}
+			case known && contains(keyfileCertKeys[name], key):
+				fmt.Fprintf(&buf, "%s=%s\n", key, formatKeyfileCert(value.([]byte)))
+			case known && (kind == kindAddressData || kind == kindRouteData):
+				if err := writeKeyfileIPEntries(&buf, key, value); err != nil {
+					return nil, fmt.Errorf("%s.%s: %v", name, key, err)
+				}
+			case name == SettingWireGuard && key == "peers":
+				peerGroups = value.([]map[string]interface{})
+			case known && kind == kindStringMap:
+				m := value.(map[string]string)
+				if keyfileInlineMaps[name] == key {
+					for _, k := range sortedStringKeys(m) {
+						fmt.Fprintf(&buf, "%s=%s\n", k, keyfileEscape(m[k], false))
+					}
+				} else if len(m) > 0 {
+					mapGroups = append(mapGroups, name+"."+key)
+				}
+			case known:
+				s, err := formatKeyfileValue(kind, value)
+				if err != nil {
+					return nil, fmt.Errorf("%s.%s: %v", name, key, err)
+				}
+				fmt.Fprintf(&buf, "%s=%s\n", key, s)
+			default:
+				if s, ok := value.(string); ok {
+					fmt.Fprintf(&buf, "%s=%s\n", key, keyfileEscape(s, false))
+				} else {
+					fmt.Fprintf(&buf, "%s=%v\n", key, value)
+				}
+			}
+		}
+		buf.WriteString("\n")
+	}
+
+	for _, path := range mapGroups {
+		i := strings.IndexByte(path, '.')
+		name, key := path[:i], path[i+1:]
+		m := settings[name][key].(map[string]string)
+		fmt.Fprintf(&buf, "[%s-%s]\n", keyfileGroupName(name), key)
+		for _, k := range sortedStringKeys(m) {
+			fmt.Fprintf(&buf, "%s=%s\n", k, keyfileEscape(m[k], false))
+		}
+		buf.WriteString("\n")
+	}
+
+	for _, peer := range peerGroups {
+		if err := writeKeyfileWireGuardPeer(&buf, peer); err != nil {
+			return nil, err
+		}
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func splitKeyfile(data []byte) ([]keyfileGroup, error) {
+	var groups []keyfileGroup
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimLeftFunc(scanner.Text(), unicode.IsSpace)
+		if text == "" || text[0] == '#' || text[0] == ';' {
+			continue
+		}
+		if text[0] == '[' {
+			end := strings.IndexByte(text, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("keyfile line %d: unterminated group header", line)
+			}
+			groups = append(groups, keyfileGroup{name: text[1:end], line: line})
+			continue
+		}
+		eq := strings.IndexByte(text, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("keyfile line %d: expected key=value", line)
+		}
+		if len(groups) == 0 {
+			return nil, fmt.Errorf("keyfile line %d: key outside of a group", line)
+		}
+		g := &groups[len(groups)-1]
+		g.entries = append(g.entries, keyfileEntry{
+			key:   strings.TrimSpace(text[:eq]),
+			value: strings.TrimLeftFunc(text[eq+1:], unicode.IsSpace),
+			line:  line,
+		})
+	}
+
+	return groups, scanner.Err()
+}
+
+func keyfileSettingName(group string) string {
+	for name, alias := range keyfileAliases {
+		if alias == group {
+			return name
+		}
+	}
+	return group
+}
+
+func keyfileGroupName(name string) string {
+	if alias, ok := keyfileAliases[name]; ok {
+		return alias
+	}
+	return name
+}
+
+// keyfileMapGroup reports whether group holds the entries of an a{ss}
+// setting key, such as [vpn-secrets] for vpn.secrets.
+func keyfileMapGroup(group string) (name, key string, ok bool) {
+	for name, keys := range settingsSchema {
+		for key, kind := range keys {
+			if kind == kindStringMap && group == keyfileGroupName(name)+"-"+key {
+				return name, key, true
+			}
+		}
+	}
+	return "", "", false
+}
+
+func keyfileGroupRank(name, connType string) int {
+	switch name {
+	case SettingConnection:
+		return 0
+	case connType:
+		return 1
+	}
+	return 2
+}
+
+func parseKeyfileValue(kind settingKind, raw string) (interface{}, error) {
+	var v interface{}
+
+	switch kind {
+	case kindString, kindBool, kindMAC:
+		v = keyfileUnescape(raw)
+	case kindInt32, kindInt64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		v = n
+	case kindUint32, kindUint64:
+		n, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		v = n
+	case kindBytes:
+		return parseKeyfileBytes(raw)
+	case kindStrings, kindIP4s, kindIP6s:
+		v = keyfileSplitList(raw)
+	case kindUint32s:
+		var list []uint32
+		for _, s := range keyfileSplitList(raw) {
+			n, err := strconv.ParseUint(s, 10, 32)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, uint32(n))
+		}
+		v = list
+	default:
+		return nil, fmt.Errorf("D-Bus type '%s' is not supported in keyfiles", kind.signature())
+	}
+
+	return kind.convert(v)
+}
+
+// parseKeyfileCert parses a certificate or private key, which is either a
+// file path, a data URI or an "ay" value as read by parseKeyfileBytes. Paths
+// become the NUL terminated "file://" URIs NetworkManager uses on D-Bus.
+func parseKeyfileCert(raw string) ([]byte, error) {
+	if strings.HasPrefix(raw, "data:;base64,") {
+		return base64.StdEncoding.DecodeString(strings.TrimPrefix(raw, "data:;base64,"))
+	}
+	if strings.HasPrefix(raw, "file://") {
+		return append([]byte(raw), 0), nil
+	}
+	if strings.HasPrefix(raw, "/") {
+		return append([]byte("file://"+raw), 0), nil
+	}
+	return parseKeyfileBytes(raw)
+}
+
+// parseKeyfileBytes parses an "ay" value, which is either a list of byte
+// values or a plain string such as an SSID.
+func parseKeyfileBytes(raw string) ([]byte, error) {
+	if strings.HasSuffix(raw, ";") && strings.Trim(raw, "0123456789;") == "" {
+		var rv []byte
+		for _, s := range keyfileSplitList(raw) {
+			n, err := strconv.ParseUint(s, 10, 8)
+			if err != nil {
+				return nil, err
+			}
+			rv = append(rv, byte(n))
+		}
+		return rv, nil
+	}
+	return []byte(keyfileUnescape(raw)), nil
+}
+
+func formatKeyfileValue(kind settingKind, v interface{}) (string, error) {
+	switch kind {
+	case kindString:
+		return keyfileEscape(v.(string), false), nil
+	case kindBool:
+		return strconv.FormatBool(v.(bool)), nil
+	case kindInt32, kindUint32, kindInt64, kindUint64:
+		return fmt.Sprint(v), nil
+	case kindBytes:
+		return formatKeyfileBytes(v.([]byte)), nil
+	case kindMAC:
+		return strings.ToUpper(net.HardwareAddr(v.([]byte)).String()), nil
+	case kindStrings:
+		return keyfileJoinList(v.([]string)), nil
+	case kindUint32s:
+		var list []string
+		for _, n := range v.([]uint32) {
+			list = append(list, strconv.FormatUint(uint64(n), 10))
+		}
+		return keyfileJoinList(list), nil
+	case kindIP4s:
+		var list []string
+		for _, ip := range v.([]uint32) {
+			list = append(list, ip4ToString(ip))
+		}
+		return keyfileJoinList(list), nil
+	case kindIP6s:
+		var list []string
+		for _, ip := range v.([][]byte) {
+			list = append(list, net.IP(ip).String())
+		}
+		return keyfileJoinList(list), nil
+	}
+	return "", fmt.Errorf("D-Bus type '%s' is not supported in keyfiles", kind.signature())
+}
+
+// formatKeyfileCert formats a certificate or private key as a file path, or
+// as a data URI if the value holds the data itself.
+func formatKeyfileCert(b []byte) string {
+	if bytes.HasPrefix(b, []byte("file://")) && bytes.HasSuffix(b, []byte{0}) {
+		return string(b[len("file://") : len(b)-1])
+	}
+	return "data:;base64," + base64.StdEncoding.EncodeToString(b)
+}
+
+func formatKeyfileBytes(b []byte) string {
+	printable := utf8.Valid(b)
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) {
+			printable = false
+		}
+	}
+	if printable && len(b) > 0 {
+		return keyfileEscape(string(b), false)
+	}
+	var list []string
+	for _, c := range b {
+		list = append(list, strconv.Itoa(int(c)))
+	}
+	return keyfileJoinList(list)
+}
+
+// writeKeyfileIPEntries writes the addresses or routes of key as numbered
+// "addressN" or "routeN" entries.
+func writeKeyfileIPEntries(buf *bytes.Buffer, key string, v interface{}) error {
+	entries, err := parseIPEntries(v)
+	if err != nil {
+		return err
+	}
+	route := strings.HasPrefix(key, "route")
+	prefix := "address"
+	if route {
+		prefix = "route"
+	}
+
+	// Route attributes other than those of the entry itself are written as
+	// "routeN_options".
+	var routes []map[string]interface{}
+	if route {
+		routes, _ = v.([]map[string]interface{})
+	}
+
+	for i, e := range entries {
+		fmt.Fprintf(buf, "%s%d=%s/%d", prefix, i+1, e.IP, e.Prefix)
+		if e.Gateway != nil || (route && e.Metric != 0) {
+			gw := ""
+			if e.Gateway != nil {
+				gw = e.Gateway.String()
+			}
+			fmt.Fprintf(buf, ",%s", gw)
+		}
+		if route && e.Metric != 0 {
+			fmt.Fprintf(buf, ",%d", e.Metric)
+		}
+		buf.WriteString("\n")
+		if i < len(routes) {
+			if options := formatKeyfileRouteOptions(routes[i]); options != "" {
+				fmt.Fprintf(buf, "%s%d_options=%s\n", prefix, i+1, options)
+			}
+		}
+	}
+	return nil
+}
+
+// parseKeyfileRouteOptions adds the attributes of a "routeN_options" entry,
+// such as "mtu=1400,onlink=true", to the route-data element route.
+func parseKeyfileRouteOptions(route map[string]interface{}, raw string) error {
+	for _, option := range strings.Split(raw, ",") {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			continue
+		}
+		eq := strings.IndexByte(option, '=')
+		if eq < 0 {
+			return fmt.Errorf("expected name=value, got %q", option)
+		}
+		name, value := option[:eq], option[eq+1:]
+
+		var err error
+		switch keyfileRouteAttributes[name] {
+		case "s":
+			route[name] = value
+		case "b":
+			route[name], err = strconv.ParseBool(value)
+		case "y":
+			var n uint64
+			n, err = strconv.ParseUint(value, 0, 8)
+			route[name] = uint8(n)
+		default:
+			var n uint64
+			n, err = strconv.ParseUint(value, 10, 32)
+			route[name] = uint32(n)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// formatKeyfileRouteOptions formats the attributes of a route-data element
+// that are not part of the "routeN" entry.
+func formatKeyfileRouteOptions(route map[string]interface{}) string {
+	return strings.Join(ipDataAttributes(route), ",")
+}
+
+// parseKeyfileWireGuardPeer parses a [wireguard-peer.<public-key>] group into
+// an element of wireguard.peers.
+func parseKeyfileWireGuardPeer(g keyfileGroup) (map[string]interface{}, error) {
+	schema := nestedSettingsSchema[SettingWireGuard+".peers"]
+	peer := map[string]interface{}{
+		"public-key": strings.TrimPrefix(g.name, keyfileWireGuardPeerPrefix),
+	}
+	for _, e := range g.entries {
+		kind, known := schema[e.key]
+		if !known {
+			peer[e.key] = keyfileUnescape(e.value)
+			continue
+		}
+		v, err := parseKeyfileValue(kind, e.value)
+		if err != nil {
+			return nil, fmt.Errorf("keyfile line %d: [%s] %s: %v", e.line, g.name, e.key, err)
+		}
+		peer[e.key] = v
+	}
+	return peer, nil
+}
+
+// writeKeyfileWireGuardPeer writes an element of wireguard.peers as a
+// [wireguard-peer.<public-key>] group.
+func writeKeyfileWireGuardPeer(buf *bytes.Buffer, peer map[string]interface{}) error {
+	schema := nestedSettingsSchema[SettingWireGuard+".peers"]
+	publicKey, _ := peer["public-key"].(string)
+	if publicKey == "" {
+		return fmt.Errorf("%s.peers: peer without public-key", SettingWireGuard)
+	}
+
+	fmt.Fprintf(buf, "[%s%s]\n", keyfileWireGuardPeerPrefix, publicKey)
+	for _, key := range sortedKeys(peer) {
+		if key == "public-key" {
+			continue
+		}
+		value := peer[key]
+		kind, known := schema[key]
+		if known {
+			v, err := kind.convert(value)
+			if err != nil {
+				return fmt.Errorf("%s.peers: %s: %v", SettingWireGuard, key, err)
+			}
+			s, err := formatKeyfileValue(kind, v)
+			if err != nil {
+				return fmt.Errorf("%s.peers: %s: %v", SettingWireGuard, key, err)
+			}
+			fmt.Fprintf(buf, "%s=%s\n", key, s)
+		} else {
+			fmt.Fprintf(buf, "%s=%v\n", key, value)
+		}
+	}
+	buf.WriteString("\n")
+	return nil
+}
+
+func keyfileSplitList(raw string) []string {
+	var list []string
+	var cur strings.Builder
+	escaped := false
+
+	for _, r := range raw {
+		switch {
+		case escaped:
+			if r != ';' {
+				cur.WriteRune('\\')
+			}
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ';':
+			list = append(list, keyfileUnescape(cur.String()))
+			cur.Reset()
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		list = append(list, keyfileUnescape(cur.String()))
+	}
+	return list
+}
+
+func keyfileJoinList(list []string) string {
+	var buf strings.Builder
+	for _, s := range list {
+		buf.WriteString(keyfileEscape(s, true))
+		buf.WriteString(";")
+	}
+	return buf.String()
+}
+
+func keyfileEscape(s string, listItem bool) string {
+	var buf strings.Builder
+	for i, r := range s {
+		switch r {
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case ';':
+			if listItem {
+				buf.WriteString(`\;`)
+			} else {
+				buf.WriteRune(r)
+			}
+		case ' ':
+			if i == 0 {
+				buf.WriteString(`\s`)
+			} else {
+				buf.WriteRune(r)
+			}
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+func keyfileUnescape(s string) string {
+	var buf strings.Builder
+	escaped := false
+	for _, r := range s {
+		if !escaped {
+			if r == '\\' {
+				escaped = true
+			} else {
+				buf.WriteRune(r)
+			}
+			continue
+		}
+		escaped = false
+		switch r {
+		case 's':
+			buf.WriteRune(' ')
+		case 'n':
+			buf.WriteRune('\n')
+		case 't':
+			buf.WriteRune('\t')
+		case 'r':
+			buf.WriteRune('\r')
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,239 @@
+package gonetworkmanager
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKeyfileRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		keyfile string
+		check   func(t *testing.T, s ConnectionSettings)
+	}{
+		{
+			name: "ethernet static",
+			keyfile: `[connection]
+id=Wired static
+uuid=4b2a64c5-1d6b-4c4e-9b5e-6f0d7a1e2c3d
+type=ethernet
+interface-name=eth0
+permissions=
+
+[ethernet]
+mac-address=00:11:22:33:44:55
+
+[ipv4]
+address1=192.168.1.10/24,192.168.1.1
+dns=8.8.8.8;1.1.1.1;
+dns-search=example.com;
+method=manual
+route1=10.0.0.0/8,192.168.1.254,100
+route1_options=mtu=1400,onlink=true,table=200
+route2=172.16.0.0/12
+
+[ipv6]
+addr-gen-mode=stable-privacy
+method=auto
+
+[proxy]
+`,
+			check: func(t *testing.T, s ConnectionSettings) {
+				if got := s[SettingIP4Config]["gateway"]; got != "192.168.1.1" {
+					t.Errorf("ipv4.gateway = %v, want 192.168.1.1", got)
+				}
+				routes := s[SettingIP4Config]["route-data"].([]map[string]interface{})
+				if len(routes) != 2 {
+					t.Fatalf("got %d routes, want 2", len(routes))
+				}
+				want := map[string]interface{}{
+					"dest": "10.0.0.0", "prefix": uint32(8), "next-hop": "192.168.1.254", "metric": uint32(100),
+					"mtu": uint32(1400), "onlink": true, "table": uint32(200),
+				}
+				if !reflect.DeepEqual(routes[0], want) {
+					t.Errorf("route 1 = %v, want %v", routes[0], want)
+				}
+				if _, ok := routes[1]["mtu"]; ok {
+					t.Errorf("route 2 has the options of route 1: %v", routes[1])
+				}
+			},
+		},
+		{
+			name: "wifi psk",
+			keyfile: `[connection]
+id=Home
+uuid=4b2a64c5-1d6b-4c4e-9b5e-6f0d7a1e2c3d
+type=wifi
+interface-name=wlan0
+
+[wifi]
+mode=infrastructure
+ssid=/home-net
+
+[wifi-security]
+auth-alg=open
+key-mgmt=wpa-psk
+psk=secret123
+
+[ipv4]
+method=auto
+`,
+			check: func(t *testing.T, s ConnectionSettings) {
+				if got := s[SettingWireless]["ssid"]; !reflect.DeepEqual(got, []byte("/home-net")) {
+					t.Errorf("wifi.ssid = %q, want \"/home-net\"", got)
+				}
+			},
+		},
+		{
+			name: "802-1x certificates",
+			keyfile: `[connection]
+id=Corp
+uuid=4b2a64c5-1d6b-4c4e-9b5e-6f0d7a1e2c3d
+type=ethernet
+
+[ethernet]
+
+[802-1x]
+ca-cert=/etc/pki/ca.pem
+client-cert=file:///etc/pki/client.pem
+eap=tls;
+identity=user
+private-key=data:;base64,a2V5
+private-key-password-flags=1
+`,
+			check: func(t *testing.T, s ConnectionSettings) {
+				tests := map[string]string{
+					"ca-cert":     "file:///etc/pki/ca.pem\x00",
+					"client-cert": "file:///etc/pki/client.pem\x00",
+					"private-key": "key",
+				}
+				for key, want := range tests {
+					if got := s[Setting8021x][key]; !reflect.DeepEqual(got, []byte(want)) {
+						t.Errorf("802-1x.%s = %q, want %q", key, got, want)
+					}
+				}
+			},
+		},
+		{
+			name: "openvpn",
+			keyfile: `[connection]
+id=office
+uuid=4b2a64c5-1d6b-4c4e-9b5e-6f0d7a1e2c3d
+type=vpn
+
+[vpn]
+connection-type=password
+password-flags=1
+remote=vpn.example.com
+service-type=org.freedesktop.NetworkManager.openvpn
+username=alice
+
+[vpn-secrets]
+password=hunter2
+
+[ipv4]
+method=auto
+`,
+			check: func(t *testing.T, s ConnectionSettings) {
+				data, _ := s[SettingVPN]["data"].(map[string]string)
+				if data["remote"] != "vpn.example.com" {
+					t.Errorf("vpn.data = %v, want remote=vpn.example.com", data)
+				}
+				secrets, _ := s[SettingVPN]["secrets"].(map[string]string)
+				if secrets["password"] != "hunter2" {
+					t.Errorf("vpn.secrets = %v, want password=hunter2", secrets)
+				}
+			},
+		},
+		{
+			name: "wireguard",
+			keyfile: `[connection]
+id=wg0
+uuid=4b2a64c5-1d6b-4c4e-9b5e-6f0d7a1e2c3d
+type=wireguard
+interface-name=wg0
+
+[wireguard]
+listen-port=51820
+private-key=yAnz5TF+lXXJte14tji3zlMNq+hd2rYUIgJBgB3fBmk=
+
+[wireguard-peer.xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=]
+allowed-ips=10.0.0.0/24;
+endpoint=vpn.example.com:51820
+persistent-keepalive=25
+
+[ipv4]
+address1=10.0.0.2/24
+method=manual
+`,
+			check: func(t *testing.T, s ConnectionSettings) {
+				peers, _ := s[SettingWireGuard]["peers"].([]map[string]interface{})
+				if len(peers) != 1 || peers[0]["public-key"] != "xTIBA5rboUvnH4htodjb6e697QjLERt1NAB4mZqp8Dg=" {
+					t.Errorf("wireguard.peers = %v", peers)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseKeyfile([]byte(tt.keyfile))
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.check(t, parsed)
+
+			data, err := MarshalKeyfile(parsed)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reparsed, err := ParseKeyfile(data)
+			if err != nil {
+				t.Fatalf("%v in\n%s", err, data)
+			}
+			if !reflect.DeepEqual(parsed, reparsed) {
+				t.Errorf("round trip changed settings\n got: %v\nwant: %v\nkeyfile:\n%s", reparsed, parsed, data)
+			}
+		})
+	}
+}
+
+func TestMarshalKeyfileRouteOptions(t *testing.T) {
+	settings := ConnectionSettings{
+		SettingConnection: {"id": "wired", "uuid": testUUID, "type": SettingWired},
+		SettingIP4Config: {
+			"method": "auto",
+			"route-data": []map[string]interface{}{
+				{"dest": "10.0.0.0", "prefix": uint32(8)},
+				{"dest": "10.1.0.0", "prefix": uint32(16), "table": uint32(100), "onlink": true},
+			},
+		},
+	}
+	data, err := MarshalKeyfile(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "\nroute2_options=onlink=true,table=100\n") {
+		t.Errorf("missing route2_options in\n%s", data)
+	}
+	if strings.Contains(string(data), "route1_options") {
+		t.Errorf("unexpected route1_options in\n%s", data)
+	}
+}
+
+func TestParseKeyfileErrors(t *testing.T) {
+	tests := []struct {
+		name, keyfile, err string
+	}{
+		{"options without route", "[connection]\nid=a\ntype=ethernet\n[ipv4]\nmethod=auto\nroute2_options=mtu=1400\n", "no matching route"},
+		{"bad option", "[connection]\nid=a\ntype=ethernet\n[ipv4]\nroute1=10.0.0.0/8\nroute1_options=mtu\n", "expected name=value"},
+		{"bad option value", "[connection]\nid=a\ntype=ethernet\n[ipv4]\nroute1=10.0.0.0/8\nroute1_options=onlink=maybe\n", "onlink"},
+	}
+	for _, tt := range tests {
+		_, err := ParseKeyfile([]byte(tt.keyfile))
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: got error %v, want %q", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	MacvlanDeviceInterface = DeviceInterface + ".Macvlan"
+
+	MacvlanDevicePropertyParent    = MacvlanDeviceInterface + ".Parent"
+	MacvlanDevicePropertyMode      = MacvlanDeviceInterface + ".Mode"
+	MacvlanDevicePropertyNoPromisc = MacvlanDeviceInterface + ".NoPromisc"
+	MacvlanDevicePropertyTap       = MacvlanDeviceInterface + ".Tap"
+)
+
+type MacvlanDevice interface {
+	Device
+
+	// GetParent gets the device the MACVLAN is on, or nil if it has none.
+	GetParent() (Device, error)
+
+	// GetMode gets the MACVLAN mode, such as "bridge" or "vepa".
+	GetMode() (string, error)
+
+	// GetNoPromisc gets whether the parent is not put in promiscuous mode.
+	GetNoPromisc() (bool, error)
+
+	// GetTap gets whether the device is a MACVTAP.
+	GetTap() (bool, error)
+}
+
+func NewMacvlanDevice(objectPath dbus.ObjectPath) (MacvlanDevice, error) {
+	var d macvlanDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type macvlanDevice struct {
+	device
+}
+
+func (d *macvlanDevice) GetParent() (Device, error) {
+	path, err := d.getObjectProperty(MacvlanDevicePropertyParent)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+	return DeviceFactory(path)
+}
+
+func (d *macvlanDevice) GetMode() (string, error) {
+	return d.getStringProperty(MacvlanDevicePropertyMode)
+}
+
+func (d *macvlanDevice) GetNoPromisc() (bool, error) {
+	return d.getBoolProperty(MacvlanDevicePropertyNoPromisc)
+}
+
+func (d *macvlanDevice) GetTap() (bool, error) {
+	return d.getBoolProperty(MacvlanDevicePropertyTap)
+}
+
+func (d *macvlanDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	parent, err := d.GetParent()
+	if err != nil {
+		return nil, err
+	}
+	if parent != nil {
+		m["Parent"] = parent.GetPath()
+	}
+	if m["Mode"], err = d.GetMode(); err != nil {
+		return nil, err
+	}
+	if m["Tap"], err = d.GetTap(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
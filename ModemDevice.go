@@ -0,0 +1,114 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	ModemDeviceInterface = DeviceInterface + ".Modem"
+
+	ModemDevicePropertyModemCapabilities   = ModemDeviceInterface + ".ModemCapabilities"
+	ModemDevicePropertyCurrentCapabilities = ModemDeviceInterface + ".CurrentCapabilities"
+	ModemDevicePropertyDeviceId            = ModemDeviceInterface + ".DeviceId"
+	ModemDevicePropertyOperatorCode        = ModemDeviceInterface + ".OperatorCode"
+	ModemDevicePropertyApn                 = ModemDeviceInterface + ".Apn"
+)
+
+type ModemDevice interface {
+	Device
+
+	// GetModemCapabilities gets the generic family of access technologies the
+	// modem supports. Not all capabilities are available at the same time.
+	GetModemCapabilities() (NmDeviceModemCapabilities, error)
+
+	// GetCurrentCapabilities gets the access technologies the modem currently
+	// supports without a firmware reload or reinitialization.
+	GetCurrentCapabilities() (NmDeviceModemCapabilities, error)
+
+	// GetDeviceId gets the identifier of the modem, such as its IMEI.
+	GetDeviceId() (string, error)
+
+	// GetOperatorCode gets the MCC and MNC of the network the modem is
+	// registered to, such as "310260".
+	GetOperatorCode() (string, error)
+
+	// GetApn gets the access point name the modem is connected to.
+	GetApn() (string, error)
+}
+
+func NewModemDevice(objectPath dbus.ObjectPath) (ModemDevice, error) {
+	var d modemDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type modemDevice struct {
+	device
+}
+
+func (d *modemDevice) GetModemCapabilities() (NmDeviceModemCapabilities, error) {
+	v, err := d.getUint32Property(ModemDevicePropertyModemCapabilities)
+	return NmDeviceModemCapabilities(v), err
+}
+
+func (d *modemDevice) GetCurrentCapabilities() (NmDeviceModemCapabilities, error) {
+	v, err := d.getUint32Property(ModemDevicePropertyCurrentCapabilities)
+	return NmDeviceModemCapabilities(v), err
+}
+
+func (d *modemDevice) GetDeviceId() (string, error) {
+	return d.getStringProperty(ModemDevicePropertyDeviceId)
+}
+
+func (d *modemDevice) GetOperatorCode() (string, error) {
+	return d.getStringProperty(ModemDevicePropertyOperatorCode)
+}
+
+func (d *modemDevice) GetApn() (string, error) {
+	return d.getStringProperty(ModemDevicePropertyApn)
+}
+
+func (d *modemDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	caps, err := d.GetModemCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	m["ModemCapabilities"] = modemCapabilityNames(caps)
+	current, err := d.GetCurrentCapabilities()
+	if err != nil {
+		return nil, err
+	}
+	m["CurrentCapabilities"] = modemCapabilityNames(current)
+	if m["DeviceId"], err = d.GetDeviceId(); err != nil {
+		return nil, err
+	}
+	if m["OperatorCode"], err = d.GetOperatorCode(); err != nil {
+		return nil, err
+	}
+	if m["Apn"], err = d.GetApn(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// modemCapabilityNames returns the names of the flags set in caps.
+func modemCapabilityNames(caps NmDeviceModemCapabilities) []string {
+	names := []string{}
+	for _, flag := range []NmDeviceModemCapabilities{
+		NmDeviceModemCapabilitiesPots,
+		NmDeviceModemCapabilitiesCdmaEvdo,
+		NmDeviceModemCapabilitiesGsmUmts,
+		NmDeviceModemCapabilitiesLte,
+		NmDeviceModemCapabilities5gnr,
+	} {
+		if caps&flag != 0 {
+			names = append(names, flag.String())
+		}
+	}
+	return names
+}
@@ -0,0 +1,84 @@
+package gonetworkmanager
+
+// GSMOptions are the options of a GSM, UMTS or LTE profile.
+type GSMOptions struct {
+	// APN is the access point name. If empty, NetworkManager looks it up in
+	// the mobile broadband provider database.
+	APN string
+
+	Username string
+	Password string
+
+	// PIN unlocks the SIM card.
+	PIN string
+
+	// NetworkID restricts the modem to the network with this MCC/MNC.
+	NetworkID string
+
+	// HomeOnly prevents connecting while roaming.
+	HomeOnly bool
+
+	// DeviceID restricts the profile to the modem with this identifier, as
+	// returned by ModemDevice.GetDeviceId.
+	DeviceID string
+}
+
+// NewGSMConnectionSettings returns a mobile broadband profile for 3GPP
+// modems, which covers LTE.
+func NewGSMConnectionSettings(id string, opts GSMOptions) (ConnectionSettings, error) {
+	gsm := map[string]interface{}{}
+	set := func(key, value string) {
+		if value != "" {
+			gsm[key] = value
+		}
+	}
+
+	set("apn", opts.APN)
+	set("username", opts.Username)
+	set("password", opts.Password)
+	set("pin", opts.PIN)
+	set("network-id", opts.NetworkID)
+	set("device-id", opts.DeviceID)
+	if opts.APN == "" {
+		gsm["auto-config"] = true
+	}
+	if opts.HomeOnly {
+		gsm["home-only"] = true
+	}
+
+	settings := newConnectionSettings(id, SettingGSM)
+	settings[SettingGSM] = gsm
+	return checkedSettings(settings)
+}
+
+// CDMAOptions are the options of a CDMA (EV-DO) profile.
+type CDMAOptions struct {
+	// Number is the number to dial, "#777" if empty.
+	Number string
+
+	Username string
+	Password string
+}
+
+// NewCDMAConnectionSettings returns a mobile broadband profile for CDMA
+// modems.
+func NewCDMAConnectionSettings(id string, opts CDMAOptions) (ConnectionSettings, error) {
+	number := opts.Number
+	if number == "" {
+		number = "#777"
+	}
+
+	cdma := map[string]interface{}{
+		"number": number,
+	}
+	if opts.Username != "" {
+		cdma["username"] = opts.Username
+	}
+	if opts.Password != "" {
+		cdma["password"] = opts.Password
+	}
+
+	settings := newConnectionSettings(id, SettingCDMA)
+	settings[SettingCDMA] = cdma
+	return checkedSettings(settings)
+}
@@ -13,8 +13,44 @@ const (
 	NetworkManagerGetDevices               = NetworkManagerInterface + ".GetDevices"
 	NetworkManagerActivateConnection       = NetworkManagerInterface + ".ActivateConnection"
 	NetworkManagerAddAndActivateConnection = NetworkManagerInterface + ".AddAndActivateConnection"
+	NetworkManagerDeactivateConnection     = NetworkManagerInterface + ".DeactivateConnection"
+	NetworkManagerCheckConnectivity        = NetworkManagerInterface + ".CheckConnectivity"
+	NetworkManagerEnable                   = NetworkManagerInterface + ".Enable"
+	NetworkManagerSleep                    = NetworkManagerInterface + ".Sleep"
+	NetworkManagerGetPermissions           = NetworkManagerInterface + ".GetPermissions"
+
+	NetworkManagerCheckpointCreate                = NetworkManagerInterface + ".CheckpointCreate"
+	NetworkManagerCheckpointDestroy               = NetworkManagerInterface + ".CheckpointDestroy"
+	NetworkManagerCheckpointRollback              = NetworkManagerInterface + ".CheckpointRollback"
+	NetworkManagerCheckpointAdjustRollbackTimeout = NetworkManagerInterface + ".CheckpointAdjustRollbackTimeout"
+	NetworkManagerPropertyCheckpoints             = NetworkManagerInterface + ".Checkpoints"
+
+	NetworkManagerSignalCheckPermissions   = "CheckPermissions"
 	NetworkManagerPropertyState            = NetworkManagerInterface + ".State"
 	NetworkManagerPropertyActiveConnection = NetworkManagerInterface + ".ActiveConnections"
+
+	NetworkManagerPropertyConnectivity               = NetworkManagerInterface + ".Connectivity"
+	NetworkManagerPropertyConnectivityCheckAvailable = NetworkManagerInterface + ".ConnectivityCheckAvailable"
+	NetworkManagerPropertyConnectivityCheckEnabled   = NetworkManagerInterface + ".ConnectivityCheckEnabled"
+	NetworkManagerPropertyConnectivityCheckUri       = NetworkManagerInterface + ".ConnectivityCheckUri"
+
+	NetworkManagerPropertyNetworkingEnabled       = NetworkManagerInterface + ".NetworkingEnabled"
+	NetworkManagerPropertyWirelessEnabled         = NetworkManagerInterface + ".WirelessEnabled"
+	NetworkManagerPropertyWirelessHardwareEnabled = NetworkManagerInterface + ".WirelessHardwareEnabled"
+	NetworkManagerPropertyWwanEnabled             = NetworkManagerInterface + ".WwanEnabled"
+	NetworkManagerPropertyWwanHardwareEnabled     = NetworkManagerInterface + ".WwanHardwareEnabled"
+	NetworkManagerPropertyWimaxEnabled            = NetworkManagerInterface + ".WimaxEnabled"
+	NetworkManagerPropertyWimaxHardwareEnabled    = NetworkManagerInterface + ".WimaxHardwareEnabled"
+
+	NetworkManagerPropertyVersion                = NetworkManagerInterface + ".Version"
+	NetworkManagerPropertyPrimaryConnection      = NetworkManagerInterface + ".PrimaryConnection"
+	NetworkManagerPropertyPrimaryConnectionType  = NetworkManagerInterface + ".PrimaryConnectionType"
+	NetworkManagerPropertyActivatingConnection   = NetworkManagerInterface + ".ActivatingConnection"
+	NetworkManagerPropertyStartup                = NetworkManagerInterface + ".Startup"
+	NetworkManagerPropertyMetered                = NetworkManagerInterface + ".Metered"
+	NetworkManagerPropertyAllDevices             = NetworkManagerInterface + ".AllDevices"
+	NetworkManagerPropertyCapabilities           = NetworkManagerInterface + ".Capabilities"
+	NetworkManagerPropertyGlobalDnsConfiguration = NetworkManagerInterface + ".GlobalDnsConfiguration"
 )
 
 type NetworkManager interface {
@@ -30,6 +66,53 @@ type NetworkManager interface {
 	// GetActiveConnections returns the active connection of network devices.
 	GetActiveConnections() ([]ActiveConnection, error)
 
+	// GetAllDevices gets the list of all network devices, including
+	// placeholders for software devices that do not exist yet.
+	GetAllDevices() ([]Device, error)
+
+	// GetVersion gets the version of the NetworkManager daemon.
+	GetVersion() (string, error)
+
+	// GetParsedVersion gets the version of the NetworkManager daemon, parsed
+	// for comparing it with the version that introduced a feature.
+	GetParsedVersion() (NmVersion, error)
+
+	// GetPrimaryConnection gets the active connection holding the default
+	// route, or nil if there is none.
+	GetPrimaryConnection() (ActiveConnection, error)
+
+	// GetPrimaryConnectionType gets the connection type of the primary
+	// connection, such as "802-3-ethernet", or "" if there is none.
+	GetPrimaryConnectionType() (string, error)
+
+	// GetActivatingConnection gets the connection that is likely to become
+	// the primary connection once activated, or nil if there is none.
+	GetActivatingConnection() (ActiveConnection, error)
+
+	// GetStartup gets whether NetworkManager is still starting up and
+	// activating the connections it found at startup.
+	GetStartup() (bool, error)
+
+	// GetMetered gets whether the primary connection is metered.
+	GetMetered() (NmMetered, error)
+
+	// GetCapabilities gets the optional features available in the daemon.
+	GetCapabilities() ([]NmCapability, error)
+
+	// GetGlobalDnsConfiguration gets the global DNS configuration, which
+	// overrides the DNS settings of the connections when not empty. It holds
+	// the "searches" and "options" lists and the "domains" map of per domain
+	// "servers" and "options".
+	GetGlobalDnsConfiguration() (map[string]interface{}, error)
+
+	// ActivateConnection activates a saved connection, such as a VPN or
+	// Bluetooth profile. device may be nil to let NetworkManager pick a
+	// suitable device.
+	ActivateConnection(connection Connection, device Device) (ActiveConnection, error)
+
+	// DeactivateConnection deactivates an active connection.
+	DeactivateConnection(connection ActiveConnection) error
+
 	// ActivateWirelessConnection requests activating access point to network device
 	ActivateWirelessConnection(connection Connection, device Device, accessPoint AccessPoint) (ActiveConnection, error)
 
@@ -43,8 +126,121 @@ type NetworkManager interface {
 	// connection["802-11-wireless-security"] = make(map[string]interface{})
 	// connection["802-11-wireless-security"]["key-mgmt"] = "wpa-psk"
 	// connection["802-11-wireless-security"]["psk"] = password
+	// Use ValidatePartial to check the partial profile first.
 	AddAndActivateWirelessConnection(connection map[string]map[string]interface{}, device Device, accessPoint AccessPoint) (ac ActiveConnection, err error)
 
+	// Enable enables or disables all networking. Disabling it deactivates all
+	// connections.
+	Enable(enable bool) error
+
+	// Sleep puts NetworkManager to sleep or wakes it up, as done around
+	// system suspend. Most users want Enable instead.
+	Sleep(sleep bool) error
+
+	// GetNetworkingEnabled gets whether networking is enabled, see Enable.
+	GetNetworkingEnabled() (bool, error)
+
+	// GetWirelessEnabled gets whether the Wi-Fi radios are enabled.
+	GetWirelessEnabled() (bool, error)
+
+	// SetWirelessEnabled enables or disables the Wi-Fi radios.
+	SetWirelessEnabled(enabled bool) error
+
+	// GetWirelessHardwareEnabled gets the state of the Wi-Fi rfkill switch.
+	GetWirelessHardwareEnabled() (bool, error)
+
+	// GetWwanEnabled gets whether the mobile broadband radios are enabled.
+	GetWwanEnabled() (bool, error)
+
+	// SetWwanEnabled enables or disables the mobile broadband radios.
+	SetWwanEnabled(enabled bool) error
+
+	// GetWwanHardwareEnabled gets the state of the mobile broadband rfkill
+	// switch.
+	GetWwanHardwareEnabled() (bool, error)
+
+	// GetWimaxEnabled gets whether the WiMAX radios are enabled. WiMAX
+	// support was removed in NetworkManager 1.2.
+	GetWimaxEnabled() (bool, error)
+
+	// SetWimaxEnabled enables or disables the WiMAX radios.
+	SetWimaxEnabled(enabled bool) error
+
+	// GetWimaxHardwareEnabled gets the state of the WiMAX rfkill switch.
+	GetWimaxHardwareEnabled() (bool, error)
+
+	// GetAirplaneMode reports whether all radios controlled by NetworkManager,
+	// Wi-Fi and mobile broadband, are disabled.
+	GetAirplaneMode() (bool, error)
+
+	// SetAirplaneMode disables all radios controlled by NetworkManager, or
+	// enables them again. WiMAX radios are switched too where they are
+	// still supported.
+	SetAirplaneMode(enabled bool) error
+
+	// CheckConnectivity re-checks the network connectivity state and returns
+	// the result.
+	CheckConnectivity() (NmConnectivity, error)
+
+	// GetConnectivity gets the network connectivity state as last checked.
+	GetConnectivity() (NmConnectivity, error)
+
+	// GetConnectivityCheckAvailable gets whether connectivity checking is
+	// configured, with a URI to check.
+	GetConnectivityCheckAvailable() (bool, error)
+
+	// GetConnectivityCheckEnabled gets whether connectivity checking is
+	// enabled.
+	GetConnectivityCheckEnabled() (bool, error)
+
+	// SetConnectivityCheckEnabled enables or disables connectivity checking.
+	SetConnectivityCheckEnabled(enabled bool) error
+
+	// GetConnectivityCheckUri gets the URI used for connectivity checking.
+	GetConnectivityCheckUri() (string, error)
+
+	// SubscribeConnectivity returns a channel receiving the connectivity
+	// state each time it changes, for example to NmConnectivityPortal when a
+	// captive portal is detected.
+	SubscribeConnectivity() <-chan NmConnectivity
+
+	// UnsubscribeConnectivity stops the subscription and closes its channel.
+	UnsubscribeConnectivity()
+
+	// GetPermissions gets which actions the caller may perform, as decided by
+	// polkit.
+	GetPermissions() (Permissions, error)
+
+	// SubscribePermissions returns a channel receiving the permissions of the
+	// caller each time NetworkManager signals that they may have changed,
+	// for example because the user session became inactive. Permissions
+	// that cannot be read after the signal are skipped.
+	SubscribePermissions() <-chan Permissions
+
+	// UnsubscribePermissions stops the subscription and closes its channel.
+	UnsubscribePermissions()
+
+	// CheckpointCreate takes a checkpoint of devices, or of all devices if
+	// none are given. Unless destroyed before, it is rolled back after
+	// rollbackTimeout seconds, or never if rollbackTimeout is 0.
+	CheckpointCreate(devices []Device, rollbackTimeout uint32, flags NmCheckpointCreateFlags) (Checkpoint, error)
+
+	// CheckpointDestroy destroys a checkpoint, keeping the current
+	// configuration.
+	CheckpointDestroy(checkpoint Checkpoint) error
+
+	// CheckpointRollback restores the configuration of a checkpoint and
+	// destroys it. It returns the result for each device, by device path.
+	CheckpointRollback(checkpoint Checkpoint) (map[dbus.ObjectPath]NmRollbackResult, error)
+
+	// CheckpointAdjustRollbackTimeout resets the rollback timeout of a
+	// checkpoint to addTimeout seconds from now, or disables it if
+	// addTimeout is 0.
+	CheckpointAdjustRollbackTimeout(checkpoint Checkpoint, addTimeout uint32) error
+
+	// GetCheckpoints gets the existing checkpoints.
+	GetCheckpoints() ([]Checkpoint, error)
+
 	Subscribe() <-chan *dbus.Signal
 	Unsubscribe()
 
@@ -60,6 +256,12 @@ type networkManager struct {
 	dbusBase
 
 	sigChan chan *dbus.Signal
+
+	connectivitySub  *signalSubscription
+	connectivityChan chan NmConnectivity
+
+	permissionsSub  *signalSubscription
+	permissionsChan chan Permissions
 }
 
 func (n *networkManager) GetDevices() ([]Device, error) {
@@ -97,7 +299,7 @@ func (n *networkManager) GetActiveConnections() ([]ActiveConnection, error) {
 	ac := make([]ActiveConnection, len(acPaths))
 
 	for i, path := range acPaths {
-		ac[i], err = NewActiveConnection(path)
+		ac[i], err = ActiveConnectionFactory(path)
 		if err != nil {
 			return nil, err
 		}
@@ -106,27 +308,393 @@ func (n *networkManager) GetActiveConnections() ([]ActiveConnection, error) {
 	return ac, nil
 }
 
+func (n *networkManager) GetAllDevices() ([]Device, error) {
+	devicePaths, err := n.getSliceObjectProperty(NetworkManagerPropertyAllDevices)
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]Device, len(devicePaths))
+
+	for i, path := range devicePaths {
+		devices[i], err = DeviceFactory(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return devices, nil
+}
+
+func (n *networkManager) GetVersion() (string, error) {
+	return n.getStringProperty(NetworkManagerPropertyVersion)
+}
+
+func (n *networkManager) GetParsedVersion() (NmVersion, error) {
+	version, err := n.GetVersion()
+	if err != nil {
+		return NmVersion{}, err
+	}
+	return ParseNmVersion(version)
+}
+
+func (n *networkManager) GetPrimaryConnection() (ActiveConnection, error) {
+	return n.getActiveConnectionProperty(NetworkManagerPropertyPrimaryConnection)
+}
+
+func (n *networkManager) GetPrimaryConnectionType() (string, error) {
+	return n.getStringProperty(NetworkManagerPropertyPrimaryConnectionType)
+}
+
+func (n *networkManager) GetActivatingConnection() (ActiveConnection, error) {
+	return n.getActiveConnectionProperty(NetworkManagerPropertyActivatingConnection)
+}
+
+func (n *networkManager) getActiveConnectionProperty(iface string) (ActiveConnection, error) {
+	path, err := n.getObjectProperty(iface)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+	return ActiveConnectionFactory(path)
+}
+
+func (n *networkManager) GetStartup() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyStartup)
+}
+
+func (n *networkManager) GetMetered() (NmMetered, error) {
+	v, err := n.getUint32Property(NetworkManagerPropertyMetered)
+	return NmMetered(v), err
+}
+
+func (n *networkManager) GetCapabilities() ([]NmCapability, error) {
+	values, err := n.getSliceUint32Property(NetworkManagerPropertyCapabilities)
+	if err != nil {
+		return nil, err
+	}
+	capabilities := make([]NmCapability, len(values))
+	for i, v := range values {
+		capabilities[i] = NmCapability(v)
+	}
+	return capabilities, nil
+}
+
+func (n *networkManager) GetGlobalDnsConfiguration() (map[string]interface{}, error) {
+	config, err := n.getMapStringVariantProperty(NetworkManagerPropertyGlobalDnsConfiguration)
+	if err != nil {
+		return nil, err
+	}
+	return plainVariantMap(config), nil
+}
+
+// plainVariantMap converts an a{sv} value to plain Go values, including the
+// a{sv} values nested in it, such as the per domain settings of the global
+// DNS configuration.
+func plainVariantMap(m map[string]dbus.Variant) map[string]interface{} {
+	rv := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		if nested, ok := v.Value().(map[string]dbus.Variant); ok {
+			rv[k] = plainVariantMap(nested)
+		} else {
+			rv[k] = v.Value()
+		}
+	}
+	return rv
+}
+
+func (n *networkManager) ActivateConnection(c Connection, d Device) (ActiveConnection, error) {
+	devicePath := dbus.ObjectPath("/")
+	if d != nil {
+		devicePath = d.GetPath()
+	}
+
+	var opath dbus.ObjectPath
+	err := n.call(&opath, NetworkManagerActivateConnection, c.GetPath(), devicePath, dbus.ObjectPath("/"))
+	if err != nil {
+		return nil, err
+	}
+	return ActiveConnectionFactory(opath)
+}
+
+func (n *networkManager) DeactivateConnection(c ActiveConnection) error {
+	return n.obj.Call(NetworkManagerDeactivateConnection, 0, c.GetPath()).Store()
+}
+
 func (n *networkManager) ActivateWirelessConnection(c Connection, d Device, ap AccessPoint) (ActiveConnection, error) {
 	var opath dbus.ObjectPath
-	return nil, n.call(&opath, NetworkManagerActivateConnection, c.GetPath(), d.GetPath(), ap.GetPath())
+	err := n.call(&opath, NetworkManagerActivateConnection, c.GetPath(), d.GetPath(), ap.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	return ActiveConnectionFactory(opath)
 }
 
 func (n *networkManager) AddAndActivateWirelessConnection(connection map[string]map[string]interface{}, d Device, ap AccessPoint) (ac ActiveConnection, err error) {
+	encoded, err := EncodeSettings(connection)
+	if err != nil {
+		return
+	}
+
 	var opath1 dbus.ObjectPath
 	var opath2 dbus.ObjectPath
 
-	err = n.call2(&opath1, &opath2, NetworkManagerAddAndActivateConnection, connection, d.GetPath(), ap.GetPath())
+	err = n.call2(&opath1, &opath2, NetworkManagerAddAndActivateConnection, encoded, d.GetPath(), ap.GetPath())
 	if err != nil {
 		return
 	}
 
-	ac, err = NewActiveConnection(opath2)
+	ac, err = ActiveConnectionFactory(opath2)
 	if err != nil {
 		return
 	}
 	return
 }
 
+func (n *networkManager) Enable(enable bool) error {
+	return n.obj.Call(NetworkManagerEnable, 0, enable).Store()
+}
+
+func (n *networkManager) Sleep(sleep bool) error {
+	return n.obj.Call(NetworkManagerSleep, 0, sleep).Store()
+}
+
+func (n *networkManager) GetNetworkingEnabled() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyNetworkingEnabled)
+}
+
+func (n *networkManager) GetWirelessEnabled() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyWirelessEnabled)
+}
+
+func (n *networkManager) SetWirelessEnabled(enabled bool) error {
+	return n.setProperty(NetworkManagerPropertyWirelessEnabled, enabled)
+}
+
+func (n *networkManager) GetWirelessHardwareEnabled() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyWirelessHardwareEnabled)
+}
+
+func (n *networkManager) GetWwanEnabled() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyWwanEnabled)
+}
+
+func (n *networkManager) SetWwanEnabled(enabled bool) error {
+	return n.setProperty(NetworkManagerPropertyWwanEnabled, enabled)
+}
+
+func (n *networkManager) GetWwanHardwareEnabled() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyWwanHardwareEnabled)
+}
+
+func (n *networkManager) GetWimaxEnabled() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyWimaxEnabled)
+}
+
+func (n *networkManager) SetWimaxEnabled(enabled bool) error {
+	return n.setProperty(NetworkManagerPropertyWimaxEnabled, enabled)
+}
+
+func (n *networkManager) GetWimaxHardwareEnabled() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyWimaxHardwareEnabled)
+}
+
+func (n *networkManager) GetAirplaneMode() (bool, error) {
+	wireless, err := n.GetWirelessEnabled()
+	if err != nil {
+		return false, err
+	}
+	wwan, err := n.GetWwanEnabled()
+	if err != nil {
+		return false, err
+	}
+	return !wireless && !wwan, nil
+}
+
+func (n *networkManager) SetAirplaneMode(enabled bool) error {
+	if err := n.SetWirelessEnabled(!enabled); err != nil {
+		return err
+	}
+	if err := n.SetWwanEnabled(!enabled); err != nil {
+		return err
+	}
+	// WiMAX no longer exists in current NetworkManager versions, so failing
+	// to switch it is not an error.
+	n.SetWimaxEnabled(!enabled)
+	return nil
+}
+
+func (n *networkManager) CheckConnectivity() (NmConnectivity, error) {
+	var connectivity uint32
+	err := n.call(&connectivity, NetworkManagerCheckConnectivity)
+	return NmConnectivity(connectivity), err
+}
+
+func (n *networkManager) GetConnectivity() (NmConnectivity, error) {
+	v, err := n.getUint32Property(NetworkManagerPropertyConnectivity)
+	return NmConnectivity(v), err
+}
+
+func (n *networkManager) GetConnectivityCheckAvailable() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyConnectivityCheckAvailable)
+}
+
+func (n *networkManager) GetConnectivityCheckEnabled() (bool, error) {
+	return n.getBoolProperty(NetworkManagerPropertyConnectivityCheckEnabled)
+}
+
+func (n *networkManager) SetConnectivityCheckEnabled(enabled bool) error {
+	return n.setProperty(NetworkManagerPropertyConnectivityCheckEnabled, enabled)
+}
+
+func (n *networkManager) GetConnectivityCheckUri() (string, error) {
+	return n.getStringProperty(NetworkManagerPropertyConnectivityCheckUri)
+}
+
+func (n *networkManager) SubscribeConnectivity() <-chan NmConnectivity {
+	if n.connectivityChan != nil {
+		return n.connectivityChan
+	}
+
+	sub := n.subscribeSignals(dbusPropertiesInterface, dbusSignalPropertiesChanged)
+	connectivityChan := make(chan NmConnectivity, 10)
+	n.connectivitySub, n.connectivityChan = sub, connectivityChan
+
+	go func() {
+		defer close(connectivityChan)
+		for {
+			sig, ok := sub.next()
+			if !ok {
+				return
+			}
+			changed, ok := changedProperties(sig, NetworkManagerInterface)
+			if !ok {
+				continue
+			}
+			v, ok := changed["Connectivity"].Value().(uint32)
+			if !ok {
+				continue
+			}
+			select {
+			case connectivityChan <- NmConnectivity(v):
+			case <-sub.closed():
+				return
+			}
+		}
+	}()
+
+	return connectivityChan
+}
+
+func (n *networkManager) UnsubscribeConnectivity() {
+	if n.connectivitySub == nil {
+		return
+	}
+	n.connectivitySub.close()
+	n.connectivitySub = nil
+	n.connectivityChan = nil
+}
+
+func (n *networkManager) GetPermissions() (Permissions, error) {
+	var raw map[string]string
+	if err := n.call(&raw, NetworkManagerGetPermissions); err != nil {
+		return nil, err
+	}
+	permissions := make(Permissions, len(raw))
+	for k, v := range raw {
+		permissions[NmPermission(k)] = NmPermissionResult(v)
+	}
+	return permissions, nil
+}
+
+func (n *networkManager) SubscribePermissions() <-chan Permissions {
+	if n.permissionsChan != nil {
+		return n.permissionsChan
+	}
+
+	sub := n.subscribeSignals(NetworkManagerInterface, NetworkManagerSignalCheckPermissions)
+	permissionsChan := make(chan Permissions, 10)
+	n.permissionsSub, n.permissionsChan = sub, permissionsChan
+
+	go func() {
+		defer close(permissionsChan)
+		for {
+			if _, ok := sub.next(); !ok {
+				return
+			}
+			permissions, err := n.GetPermissions()
+			if err != nil {
+				continue
+			}
+			select {
+			case permissionsChan <- permissions:
+			case <-sub.closed():
+				return
+			}
+		}
+	}()
+
+	return permissionsChan
+}
+
+func (n *networkManager) UnsubscribePermissions() {
+	if n.permissionsSub == nil {
+		return
+	}
+	n.permissionsSub.close()
+	n.permissionsSub = nil
+	n.permissionsChan = nil
+}
+
+func (n *networkManager) CheckpointCreate(devices []Device, rollbackTimeout uint32, flags NmCheckpointCreateFlags) (Checkpoint, error) {
+	devicePaths := make([]dbus.ObjectPath, len(devices))
+	for i, d := range devices {
+		devicePaths[i] = d.GetPath()
+	}
+
+	var opath dbus.ObjectPath
+	err := n.call(&opath, NetworkManagerCheckpointCreate, devicePaths, rollbackTimeout, uint32(flags))
+	if err != nil {
+		return nil, err
+	}
+	return NewCheckpoint(opath)
+}
+
+func (n *networkManager) CheckpointDestroy(c Checkpoint) error {
+	return n.obj.Call(NetworkManagerCheckpointDestroy, 0, c.GetPath()).Store()
+}
+
+func (n *networkManager) CheckpointRollback(c Checkpoint) (map[dbus.ObjectPath]NmRollbackResult, error) {
+	var raw map[string]uint32
+	if err := n.call(&raw, NetworkManagerCheckpointRollback, c.GetPath()); err != nil {
+		return nil, err
+	}
+	results := make(map[dbus.ObjectPath]NmRollbackResult, len(raw))
+	for path, result := range raw {
+		results[dbus.ObjectPath(path)] = NmRollbackResult(result)
+	}
+	return results, nil
+}
+
+func (n *networkManager) CheckpointAdjustRollbackTimeout(c Checkpoint, addTimeout uint32) error {
+	return n.obj.Call(NetworkManagerCheckpointAdjustRollbackTimeout, 0, c.GetPath(), addTimeout).Store()
+}
+
+func (n *networkManager) GetCheckpoints() ([]Checkpoint, error) {
+	paths, err := n.getSliceObjectProperty(NetworkManagerPropertyCheckpoints)
+	if err != nil {
+		return nil, err
+	}
+	checkpoints := make([]Checkpoint, len(paths))
+
+	for i, path := range paths {
+		checkpoints[i], err = NewCheckpoint(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return checkpoints, nil
+}
+
 func (n *networkManager) Subscribe() <-chan *dbus.Signal {
 	if n.sigChan != nil {
 		return n.sigChan
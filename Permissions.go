@@ -0,0 +1,55 @@
+package gonetworkmanager
+
+// NmPermission is the name of a polkit action of NetworkManager, as returned
+// by NetworkManager.GetPermissions.
+type NmPermission string
+
+const (
+	NmPermissionEnableDisableNetwork           NmPermission = NetworkManagerInterface + ".enable-disable-network"
+	NmPermissionEnableDisableWifi              NmPermission = NetworkManagerInterface + ".enable-disable-wifi"
+	NmPermissionEnableDisableWwan              NmPermission = NetworkManagerInterface + ".enable-disable-wwan"
+	NmPermissionEnableDisableWimax             NmPermission = NetworkManagerInterface + ".enable-disable-wimax"
+	NmPermissionSleepWake                      NmPermission = NetworkManagerInterface + ".sleep-wake"
+	NmPermissionNetworkControl                 NmPermission = NetworkManagerInterface + ".network-control"
+	NmPermissionWifiShareProtected             NmPermission = NetworkManagerInterface + ".wifi.share.protected"
+	NmPermissionWifiShareOpen                  NmPermission = NetworkManagerInterface + ".wifi.share.open"
+	NmPermissionSettingsModifySystem           NmPermission = NetworkManagerInterface + ".settings.modify.system"
+	NmPermissionSettingsModifyOwn              NmPermission = NetworkManagerInterface + ".settings.modify.own"
+	NmPermissionSettingsModifyHostname         NmPermission = NetworkManagerInterface + ".settings.modify.hostname"
+	NmPermissionSettingsModifyGlobalDns        NmPermission = NetworkManagerInterface + ".settings.modify.global-dns"
+	NmPermissionReload                         NmPermission = NetworkManagerInterface + ".reload"
+	NmPermissionCheckpointRollback             NmPermission = NetworkManagerInterface + ".checkpoint-rollback"
+	NmPermissionEnableDisableStatistics        NmPermission = NetworkManagerInterface + ".enable-disable-statistics"
+	NmPermissionEnableDisableConnectivityCheck NmPermission = NetworkManagerInterface + ".enable-disable-connectivity-check"
+	NmPermissionWifiScan                       NmPermission = NetworkManagerInterface + ".wifi.scan"
+)
+
+// NmPermissionResult tells whether the caller may perform an action.
+type NmPermissionResult string
+
+const (
+	// NmPermissionResultYes means the action is allowed.
+	NmPermissionResultYes NmPermissionResult = "yes"
+
+	// NmPermissionResultNo means the action is denied.
+	NmPermissionResultNo NmPermissionResult = "no"
+
+	// NmPermissionResultAuth means the action is allowed once the user
+	// authenticates through a polkit agent.
+	NmPermissionResultAuth NmPermissionResult = "auth"
+)
+
+// Permissions maps the actions of NetworkManager to whether the caller may
+// perform them.
+type Permissions map[NmPermission]NmPermissionResult
+
+// Allowed reports whether permission is granted without authentication.
+func (p Permissions) Allowed(permission NmPermission) bool {
+	return p[permission] == NmPermissionResultYes
+}
+
+// Denied reports whether permission is denied, or unknown to the daemon.
+func (p Permissions) Denied(permission NmPermission) bool {
+	result, ok := p[permission]
+	return !ok || result == NmPermissionResultNo
+}
@@ -0,0 +1,230 @@
+package gonetworkmanager
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+)
+
+//go:generate stringer -type=ReconcileAction
+type ReconcileAction uint32
+
+const (
+	ReconcileActionNone   ReconcileAction = 0
+	ReconcileActionAdd    ReconcileAction = 1
+	ReconcileActionUpdate ReconcileAction = 2
+	ReconcileActionDelete ReconcileAction = 3
+)
+
+// ReconcileChange is a single step of a ReconcilePlan.
+type ReconcileChange struct {
+	Action ReconcileAction
+	UUID   string
+	ID     string
+
+	// Connection is the existing connection, nil when it is to be added.
+	Connection Connection
+
+	// Settings are the desired settings, nil when the connection is to be
+	// deleted.
+	Settings ConnectionSettings
+
+	// Keys lists the "setting.key" paths that differ for an update.
+	Keys []string
+}
+
+// ReconcilePlan is the result of Reconcile.
+type ReconcilePlan struct {
+	Changes []ReconcileChange
+
+	// Applied is false for a dry run, or if applying a change failed.
+	Applied bool
+}
+
+// ReconcileOptions controls how Reconcile treats existing connections.
+type ReconcileOptions struct {
+	// DryRun only computes the plan without changing anything.
+	DryRun bool
+
+	// DeleteUnmanaged deletes existing connections that are not part of the
+	// desired set.
+	DeleteUnmanaged bool
+
+	// Managed restricts which existing connections DeleteUnmanaged may delete.
+	// If nil, all of them are considered managed.
+	Managed func(settings ConnectionSettings) bool
+}
+
+// Reconcile compares the desired connection profiles, identified by their
+// connection.uuid, with the connections saved in NetworkManager. It adds the
+// missing ones, updates those whose settings differ and, if requested,
+// deletes the ones not in the desired set. Only the keys present in a desired
+// profile are compared, keys NetworkManager leaves out are taken to have their
+// default value, and secrets are ignored since NetworkManager does not return
+// them.
+func Reconcile(s Settings, desired []ConnectionSettings, opts ReconcileOptions) (*ReconcilePlan, error) {
+	wanted := make(map[string]ConnectionSettings, len(desired))
+	for i, d := range desired {
+		uuid, _ := d[SettingConnection]["uuid"].(string)
+		if uuid == "" {
+			return nil, fmt.Errorf("desired connection %d has no connection.uuid", i)
+		}
+		if _, dup := wanted[uuid]; dup {
+			return nil, fmt.Errorf("duplicate desired connection %s", uuid)
+		}
+		if errs := Validate(d); len(errs) > 0 {
+			return nil, errs
+		}
+		wanted[uuid] = d
+	}
+
+	connections, err := s.ListConnections()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ReconcilePlan{}
+	existing := make(map[string]bool)
+
+	for _, c := range connections {
+		current := c.GetSettings()
+		uuid, _ := current[SettingConnection]["uuid"].(string)
+		id, _ := current[SettingConnection]["id"].(string)
+		if uuid == "" {
+			// GetSettings returns no settings if the call failed, which must
+			// not be taken for an unmanaged connection.
+			return nil, fmt.Errorf("connection %s: could not get its connection.uuid", c.GetPath())
+		}
+		existing[uuid] = true
+
+		d, ok := wanted[uuid]
+		if !ok {
+			if opts.DeleteUnmanaged && (opts.Managed == nil || opts.Managed(current)) {
+				plan.Changes = append(plan.Changes, ReconcileChange{
+					Action:     ReconcileActionDelete,
+					UUID:       uuid,
+					ID:         id,
+					Connection: c,
+				})
+			}
+			continue
+		}
+
+		change := ReconcileChange{
+			Action:     ReconcileActionNone,
+			UUID:       uuid,
+			ID:         id,
+			Connection: c,
+			Settings:   d,
+			Keys:       changedSettingKeys(d, current),
+		}
+		if len(change.Keys) > 0 {
+			change.Action = ReconcileActionUpdate
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	for uuid, d := range wanted {
+		if existing[uuid] {
+			continue
+		}
+		id, _ := d[SettingConnection]["id"].(string)
+		plan.Changes = append(plan.Changes, ReconcileChange{
+			Action:   ReconcileActionAdd,
+			UUID:     uuid,
+			ID:       id,
+			Settings: d,
+		})
+	}
+
+	sort.SliceStable(plan.Changes, func(i, j int) bool {
+		a, b := plan.Changes[i], plan.Changes[j]
+		if a.Action != b.Action {
+			return a.Action < b.Action
+		}
+		return a.UUID < b.UUID
+	})
+
+	if opts.DryRun {
+		return plan, nil
+	}
+
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		switch change.Action {
+		case ReconcileActionAdd:
+			change.Connection, err = s.AddConnection(change.Settings)
+		case ReconcileActionUpdate:
+			err = change.Connection.Update(change.Settings)
+		case ReconcileActionDelete:
+			err = change.Connection.Delete()
+		}
+		if err != nil {
+			return plan, fmt.Errorf("%s %s: %v", change.Action, change.UUID, err)
+		}
+	}
+	plan.Applied = true
+
+	return plan, nil
+}
+
+// HasChanges reports whether the plan adds, updates or deletes anything.
+func (p *ReconcilePlan) HasChanges() bool {
+	for _, c := range p.Changes {
+		if c.Action != ReconcileActionNone {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns a human readable report of the plan, one connection per
+// line prefixed with "+" for additions, "~" for updates, "-" for deletions
+// and "=" for unchanged connections.
+func (p *ReconcilePlan) String() string {
+	var buf bytes.Buffer
+	for _, c := range p.Changes {
+		mark := map[ReconcileAction]string{
+			ReconcileActionNone:   "=",
+			ReconcileActionAdd:    "+",
+			ReconcileActionUpdate: "~",
+			ReconcileActionDelete: "-",
+		}[c.Action]
+		fmt.Fprintf(&buf, "%s %s (%s)", mark, c.UUID, c.ID)
+		if len(c.Keys) > 0 {
+			fmt.Fprintf(&buf, ": %v", c.Keys)
+		}
+		buf.WriteString("\n")
+	}
+	return buf.String()
+}
+
+// changedSettingKeys returns the non-secret keys of desired whose values
+// differ from current, compared as by Diff. A key missing from current only
+// differs if the desired value is not its default.
+func changedSettingKeys(desired, current ConnectionSettings) []string {
+	d, _ := normalizeSettings(desired)
+	c, _ := normalizeSettings(current)
+
+	var keys []string
+	for _, name := range sortedSettingNames(d) {
+		for _, key := range sortedKeys(d[name]) {
+			if isSecretSettingKey(name, key) {
+				continue
+			}
+			dv := withoutNestedSecrets(name, key, d[name][key])
+			cv, ok := c[name][key]
+			if !ok {
+				if !isDefaultSettingValue(name, key, dv) {
+					keys = append(keys, name+"."+key)
+				}
+				continue
+			}
+			cv = withoutNestedSecrets(name, key, cv)
+			if !settingValuesEqual(name, key, dv, cv) {
+				keys = append(keys, name+"."+key)
+			}
+		}
+	}
+	return keys
+}
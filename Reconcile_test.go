@@ -0,0 +1,165 @@
+package gonetworkmanager
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/godbus/dbus"
+)
+
+type fakeSettings struct {
+	Settings
+	connections []Connection
+}
+
+func (s *fakeSettings) ListConnections() ([]Connection, error) {
+	return s.connections, nil
+}
+
+type fakeConnection struct {
+	Connection
+	path     dbus.ObjectPath
+	settings ConnectionSettings
+}
+
+func (c *fakeConnection) GetPath() dbus.ObjectPath {
+	return c.path
+}
+
+func (c *fakeConnection) GetSettings() ConnectionSettings {
+	return c.settings
+}
+
+const testUUID2 = "9d3c0f3e-54a2-4d7f-8b3e-2f1c7a6b5d40"
+
+func TestReconcilePlan(t *testing.T) {
+	wired := func(uuid string, extra map[string]interface{}) ConnectionSettings {
+		s := ConnectionSettings{
+			SettingConnection: {"id": "wired", "uuid": uuid, "type": SettingWired},
+			SettingIP4Config:  {"method": "auto"},
+		}
+		for k, v := range extra {
+			s[SettingIP4Config][k] = v
+		}
+		return s
+	}
+
+	tests := []struct {
+		name     string
+		desired  []ConnectionSettings
+		existing []ConnectionSettings
+		opts     ReconcileOptions
+		actions  []ReconcileAction
+		keys     []string
+	}{
+		{
+			name:    "add",
+			desired: []ConnectionSettings{wired(testUUID, nil)},
+			actions: []ReconcileAction{ReconcileActionAdd},
+		},
+		{
+			name:     "unchanged with defaults left out by NetworkManager",
+			desired:  []ConnectionSettings{wired(testUUID, map[string]interface{}{"may-fail": true, "dns": []string{}})},
+			existing: []ConnectionSettings{wired(testUUID, nil)},
+			actions:  []ReconcileAction{ReconcileActionNone},
+		},
+		{
+			name: "unchanged autoconnect",
+			desired: []ConnectionSettings{{
+				SettingConnection: {"id": "wired", "uuid": testUUID, "type": SettingWired, "autoconnect": true},
+			}},
+			existing: []ConnectionSettings{{
+				SettingConnection: {"id": "wired", "uuid": testUUID, "type": SettingWired, "timestamp": uint64(1)},
+			}},
+			actions: []ReconcileAction{ReconcileActionNone},
+		},
+		{
+			name:     "update",
+			desired:  []ConnectionSettings{wired(testUUID, map[string]interface{}{"may-fail": false, "dns": []string{"8.8.8.8"}})},
+			existing: []ConnectionSettings{wired(testUUID, map[string]interface{}{"dns": []uint32{0x01010101}})},
+			actions:  []ReconcileAction{ReconcileActionUpdate},
+			keys:     []string{"ipv4.dns", "ipv4.may-fail"},
+		},
+		{
+			name:     "unmanaged kept",
+			existing: []ConnectionSettings{wired(testUUID, nil)},
+		},
+		{
+			name: "route attribute changed",
+			desired: []ConnectionSettings{wired(testUUID, map[string]interface{}{"route-data": []map[string]interface{}{
+				{"dest": "10.0.0.0", "prefix": 8, "table": 200, "mtu": 1400},
+			}})},
+			existing: []ConnectionSettings{wired(testUUID, map[string]interface{}{"route-data": []map[string]interface{}{
+				{"dest": "10.0.0.0", "prefix": uint32(8), "table": uint32(100), "mtu": uint32(1400)},
+			}})},
+			actions: []ReconcileAction{ReconcileActionUpdate},
+			keys:    []string{"ipv4.route-data"},
+		},
+		{
+			name: "route attributes unchanged",
+			desired: []ConnectionSettings{wired(testUUID, map[string]interface{}{"route-data": []map[string]interface{}{
+				{"dest": "10.0.0.0", "prefix": 8, "table": 100, "onlink": true},
+			}})},
+			existing: []ConnectionSettings{wired(testUUID, map[string]interface{}{"route-data": []map[string]interface{}{
+				{"dest": "10.0.0.0", "prefix": uint32(8), "table": uint32(100), "onlink": true},
+			}})},
+			actions: []ReconcileAction{ReconcileActionNone},
+		},
+		{
+			name:     "delete unmanaged",
+			desired:  []ConnectionSettings{wired(testUUID, nil)},
+			existing: []ConnectionSettings{wired(testUUID, nil), wired(testUUID2, nil)},
+			opts:     ReconcileOptions{DeleteUnmanaged: true},
+			actions:  []ReconcileAction{ReconcileActionNone, ReconcileActionDelete},
+		},
+		{
+			name:     "delete only managed",
+			existing: []ConnectionSettings{wired(testUUID, nil), wired(testUUID2, nil)},
+			opts: ReconcileOptions{DeleteUnmanaged: true, Managed: func(s ConnectionSettings) bool {
+				return s[SettingConnection]["uuid"] == testUUID2
+			}},
+			actions: []ReconcileAction{ReconcileActionDelete},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &fakeSettings{}
+			for i, e := range tt.existing {
+				s.connections = append(s.connections, &fakeConnection{path: dbus.ObjectPath("/c/" + string(rune('0'+i))), settings: e})
+			}
+			tt.opts.DryRun = true
+
+			plan, err := Reconcile(s, tt.desired, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if plan.Applied {
+				t.Error("dry run applied the plan")
+			}
+			var actions []ReconcileAction
+			var keys []string
+			for _, c := range plan.Changes {
+				actions = append(actions, c.Action)
+				keys = append(keys, c.Keys...)
+			}
+			if !reflect.DeepEqual(actions, tt.actions) {
+				t.Errorf("got actions %v, want %v\n%s", actions, tt.actions, plan)
+			}
+			if !reflect.DeepEqual(keys, tt.keys) {
+				t.Errorf("got keys %v, want %v", keys, tt.keys)
+			}
+		})
+	}
+}
+
+func TestReconcileUnreadableConnection(t *testing.T) {
+	s := &fakeSettings{connections: []Connection{
+		&fakeConnection{path: "/c/0", settings: ConnectionSettings{}},
+	}}
+	plan, err := Reconcile(s, nil, ReconcileOptions{DryRun: true, DeleteUnmanaged: true})
+	if err == nil || !strings.Contains(err.Error(), "/c/0") {
+		t.Fatalf("got plan %v and error %v, want an error for /c/0", plan, err)
+	}
+}
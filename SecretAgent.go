@@ -0,0 +1,146 @@
+package gonetworkmanager
+
+import (
+	"errors"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	SecretAgentInterface  = NetworkManagerInterface + ".SecretAgent"
+	SecretAgentObjectPath = NetworkManagerObjectPath + "/SecretAgent"
+
+	SecretAgentErrorPermissionDenied  = SecretAgentInterface + ".PermissionDenied"
+	SecretAgentErrorInvalidConnection = SecretAgentInterface + ".InvalidConnection"
+	SecretAgentErrorUserCanceled      = SecretAgentInterface + ".UserCanceled"
+	SecretAgentErrorAgentCanceled     = SecretAgentInterface + ".AgentCanceled"
+	SecretAgentErrorNoSecrets         = SecretAgentInterface + ".NoSecrets"
+	SecretAgentErrorFailed            = SecretAgentInterface + ".Failed"
+)
+
+// Errors returned by a SecretAgent are sent to NetworkManager as the matching
+// SecretAgentError* D-Bus error, or as SecretAgentErrorFailed for any other
+// error.
+var (
+	ErrSecretAgentPermissionDenied  = errors.New("permission denied")
+	ErrSecretAgentInvalidConnection = errors.New("invalid connection")
+	ErrSecretAgentUserCanceled      = errors.New("user canceled")
+	ErrSecretAgentAgentCanceled     = errors.New("agent canceled")
+	ErrSecretAgentNoSecrets         = errors.New("no secrets")
+)
+
+var secretAgentErrorNames = map[error]string{
+	ErrSecretAgentPermissionDenied:  SecretAgentErrorPermissionDenied,
+	ErrSecretAgentInvalidConnection: SecretAgentErrorInvalidConnection,
+	ErrSecretAgentUserCanceled:      SecretAgentErrorUserCanceled,
+	ErrSecretAgentAgentCanceled:     SecretAgentErrorAgentCanceled,
+	ErrSecretAgentNoSecrets:         SecretAgentErrorNoSecrets,
+}
+
+// SecretAgent provides network secrets to NetworkManager when it activates a
+// connection. Use RegisterSecretAgent to make it available on the bus. The
+// methods may be called concurrently, in particular CancelGetSecrets while a
+// GetSecrets call is waiting for user input.
+type SecretAgent interface {
+
+	// GetSecrets retrieves the secrets of the setting settingName of the
+	// connection, which is passed without its secrets. The returned settings
+	// only need to hold that setting with its secret keys. hints may name the
+	// specific keys NetworkManager needs, and flags tell whether user
+	// interaction is allowed and whether previous secrets were wrong.
+	GetSecrets(connection ConnectionSettings, connectionPath dbus.ObjectPath, settingName string, hints []string, flags NmSecretAgentGetSecretsFlags) (ConnectionSettings, error)
+
+	// CancelGetSecrets cancels a pending GetSecrets request, which should then
+	// return ErrSecretAgentAgentCanceled.
+	CancelGetSecrets(connectionPath dbus.ObjectPath, settingName string) error
+
+	// SaveSecrets saves the secrets of a connection whose secrets are owned by
+	// the agent.
+	SaveSecrets(connection ConnectionSettings, connectionPath dbus.ObjectPath) error
+
+	// DeleteSecrets deletes the secrets of a connection that is being
+	// deleted.
+	DeleteSecrets(connection ConnectionSettings, connectionPath dbus.ObjectPath) error
+}
+
+// SecretAgentRegistration is a SecretAgent registered with RegisterSecretAgent.
+type SecretAgentRegistration interface {
+
+	// Unregister unregisters the agent from NetworkManager and removes it
+	// from the bus.
+	Unregister() error
+}
+
+// RegisterSecretAgent exports agent on the system bus at SecretAgentObjectPath
+// and registers it with the NetworkManager AgentManager under identifier. It
+// stays registered across restarts of NetworkManager.
+func RegisterSecretAgent(agent SecretAgent, identifier string, capabilities NmSecretAgentCapabilities) (SecretAgentRegistration, error) {
+	var r secretAgentRegistration
+	if err := r.manager.init(NetworkManagerInterface, AgentManagerObjectPath); err != nil {
+		return nil, err
+	}
+
+	err := r.manager.conn.Export(&secretAgentExport{agent}, SecretAgentObjectPath, SecretAgentInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.manager.KeepRegistered(identifier, capabilities)
+	if err != nil {
+		r.manager.conn.Export(nil, SecretAgentObjectPath, SecretAgentInterface)
+		return nil, err
+	}
+
+	return &r, nil
+}
+
+type secretAgentRegistration struct {
+	manager agentManager
+}
+
+func (r *secretAgentRegistration) Unregister() error {
+	err := r.manager.Unregister()
+	r.manager.conn.Export(nil, SecretAgentObjectPath, SecretAgentInterface)
+	return err
+}
+
+// secretAgentExport adapts a SecretAgent to the method signatures exported
+// through godbus.
+type secretAgentExport struct {
+	agent SecretAgent
+}
+
+func (e *secretAgentExport) GetSecrets(connection map[string]map[string]dbus.Variant, connectionPath dbus.ObjectPath, settingName string, hints []string, flags uint32) (map[string]map[string]dbus.Variant, *dbus.Error) {
+	secrets, err := e.agent.GetSecrets(DecodeSettings(connection), connectionPath, settingName, hints, NmSecretAgentGetSecretsFlags(flags))
+	if err != nil {
+		return nil, makeSecretAgentError(err)
+	}
+	encoded, err := EncodeSettings(secrets)
+	if err != nil {
+		return nil, makeSecretAgentError(err)
+	}
+	return encoded, nil
+}
+
+func (e *secretAgentExport) CancelGetSecrets(connectionPath dbus.ObjectPath, settingName string) *dbus.Error {
+	return makeSecretAgentError(e.agent.CancelGetSecrets(connectionPath, settingName))
+}
+
+func (e *secretAgentExport) SaveSecrets(connection map[string]map[string]dbus.Variant, connectionPath dbus.ObjectPath) *dbus.Error {
+	return makeSecretAgentError(e.agent.SaveSecrets(DecodeSettings(connection), connectionPath))
+}
+
+func (e *secretAgentExport) DeleteSecrets(connection map[string]map[string]dbus.Variant, connectionPath dbus.ObjectPath) *dbus.Error {
+	return makeSecretAgentError(e.agent.DeleteSecrets(DecodeSettings(connection), connectionPath))
+}
+
+func makeSecretAgentError(err error) *dbus.Error {
+	if err == nil {
+		return nil
+	}
+	name, ok := secretAgentErrorNames[err]
+	if !ok {
+		name = SecretAgentErrorFailed
+	}
+	return dbus.NewError(name, []interface{}{err.Error()})
+}
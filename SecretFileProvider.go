@@ -0,0 +1,138 @@
+package gonetworkmanager
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// FileSecretProvider is a SecretProvider that keeps secrets in a file
+// encrypted with AES-GCM. The file is rewritten on every change.
+type FileSecretProvider struct {
+	MemorySecretProvider
+
+	path    string
+	aead    cipher.AEAD
+	writeMu sync.Mutex
+}
+
+// NewFileSecretProvider opens the secret store at path, which is created on
+// the first change if it does not exist. key must be 16, 24 or 32 bytes long
+// to select AES-128, AES-192 or AES-256.
+func NewFileSecretProvider(path string, key []byte) (*FileSecretProvider, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &FileSecretProvider{path: path, aead: aead}
+	p.secrets = make(map[string]ConnectionSettings)
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := p.decode(data); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Set sets the secret keys of a setting of the connection uuid and writes the
+// file.
+func (p *FileSecretProvider) Set(uuid, settingName string, secrets map[string]interface{}) error {
+	return p.SaveSecrets(uuid, ConnectionSettings{settingName: secrets})
+}
+
+func (p *FileSecretProvider) SaveSecrets(uuid string, secrets ConnectionSettings) error {
+	p.MemorySecretProvider.SaveSecrets(uuid, secrets)
+	return p.write()
+}
+
+func (p *FileSecretProvider) DeleteSecrets(uuid string) error {
+	p.MemorySecretProvider.DeleteSecrets(uuid)
+	return p.write()
+}
+
+func (p *FileSecretProvider) decode(data []byte) error {
+	size := p.aead.NonceSize()
+	if len(data) < size {
+		return errors.New("secret file is truncated")
+	}
+	plain, err := p.aead.Open(nil, data[:size], data[size:], nil)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(plain, &p.secrets); err != nil {
+		return err
+	}
+
+	// JSON loses the Go types, restore them from the schema. []byte values
+	// were encoded as base64 strings.
+	for _, settings := range p.secrets {
+		for name, group := range settings {
+			for key, value := range group {
+				kind, ok := settingsSchema[name][key]
+				if !ok {
+					continue
+				}
+				if s, ok := value.(string); ok && kind == kindBytes {
+					if b, err := base64.StdEncoding.DecodeString(s); err == nil {
+						value = b
+					}
+				}
+				if v, err := kind.convert(value); err == nil {
+					group[key] = v
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (p *FileSecretProvider) write() error {
+	p.writeMu.Lock()
+	defer p.writeMu.Unlock()
+
+	p.mu.Lock()
+	plain, err := json.Marshal(p.secrets)
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, p.aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+	data := p.aead.Seal(nonce, nonce, plain, nil)
+
+	tmp, err := ioutil.TempFile(filepath.Dir(p.path), filepath.Base(p.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), p.path)
+}
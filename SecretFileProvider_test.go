@@ -0,0 +1,36 @@
+package gonetworkmanager
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileSecretProviderSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets")
+	key := make([]byte, 32)
+
+	p, err := NewFileSecretProvider(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Set(testUUID, SettingWirelessSecurity, map[string]interface{}{"psk": "secret123"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewFileSecretProvider(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := reopened.GetSecrets(SecretRequest{UUID: testUUID, SettingName: SettingWirelessSecurity})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]interface{}{"psk": "secret123"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := NewFileSecretProvider(path, make([]byte, 16)); err == nil {
+		t.Error("opened the file with the wrong key")
+	}
+}
@@ -0,0 +1,326 @@
+package gonetworkmanager
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/godbus/dbus"
+)
+
+// SecretRequest describes the secrets NetworkManager asks for.
+type SecretRequest struct {
+	UUID        string
+	SettingName string
+
+	// Connection holds the connection settings without secrets.
+	Connection ConnectionSettings
+
+	Hints []string
+	Flags NmSecretAgentGetSecretsFlags
+
+	// Cancel is closed when NetworkManager cancels the request.
+	Cancel <-chan struct{}
+}
+
+// SecretProvider supplies and stores the secrets used by a
+// SecretProviderAgent. Secrets are kept per connection UUID, in the same
+// shape as ConnectionSettings.
+type SecretProvider interface {
+
+	// GetSecrets returns the secret keys of the requested setting, or
+	// ErrSecretAgentNoSecrets if the provider has none.
+	GetSecrets(request SecretRequest) (map[string]interface{}, error)
+
+	// SaveSecrets stores the secrets of the connection with the given UUID.
+	SaveSecrets(uuid string, secrets ConnectionSettings) error
+
+	// DeleteSecrets removes all secrets of the connection with the given UUID.
+	DeleteSecrets(uuid string) error
+}
+
+// SecretProviderAgent is a SecretAgent that answers requests from
+// SecretProviders chosen by connection UUID and setting name.
+//
+// Stored secrets are looked up first. When there are none, or NetworkManager
+// reported them as wrong with NmSecretAgentGetSecretsFlagRequestNew, and the
+// request allows interaction, the Interactive provider is asked and the
+// secrets it returns are saved to the stored provider. The request fails if
+// they cannot be saved.
+type SecretProviderAgent struct {
+	// Default is used for connections without a specific provider.
+	Default SecretProvider
+
+	// Interactive, if set, is asked for secrets when the request allows
+	// interaction, typically to prompt the user.
+	Interactive SecretProvider
+
+	mu        sync.Mutex
+	providers map[string]SecretProvider
+	pending   map[string]chan struct{}
+}
+
+// NewSecretProviderAgent returns an agent using provider for all connections.
+func NewSecretProviderAgent(provider SecretProvider) *SecretProviderAgent {
+	return &SecretProviderAgent{
+		Default:   provider,
+		providers: make(map[string]SecretProvider),
+		pending:   make(map[string]chan struct{}),
+	}
+}
+
+// SetProvider sets the provider for the setting settingName of the
+// connection uuid. An empty uuid or settingName matches any.
+func (a *SecretProviderAgent) SetProvider(uuid, settingName string, provider SecretProvider) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.providers == nil {
+		a.providers = make(map[string]SecretProvider)
+	}
+	a.providers[uuid+"/"+settingName] = provider
+}
+
+func (a *SecretProviderAgent) provider(uuid, settingName string) SecretProvider {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, key := range []string{uuid + "/" + settingName, uuid + "/", "/" + settingName} {
+		if p, ok := a.providers[key]; ok {
+			return p
+		}
+	}
+	return a.Default
+}
+
+func (a *SecretProviderAgent) GetSecrets(connection ConnectionSettings, connectionPath dbus.ObjectPath, settingName string, hints []string, flags NmSecretAgentGetSecretsFlags) (ConnectionSettings, error) {
+	uuid, _ := connection[SettingConnection]["uuid"].(string)
+	cancel := make(chan struct{})
+	key := string(connectionPath) + "/" + settingName
+
+	a.mu.Lock()
+	if a.pending == nil {
+		a.pending = make(map[string]chan struct{})
+	}
+	a.pending[key] = cancel
+	a.mu.Unlock()
+	defer func() {
+		a.mu.Lock()
+		if a.pending[key] == cancel {
+			delete(a.pending, key)
+		}
+		a.mu.Unlock()
+	}()
+
+	request := SecretRequest{
+		UUID:        uuid,
+		SettingName: settingName,
+		Connection:  connection,
+		Hints:       hints,
+		Flags:       flags,
+		Cancel:      cancel,
+	}
+
+	stored := a.provider(uuid, settingName)
+	if stored != nil && flags&NmSecretAgentGetSecretsFlagRequestNew == 0 {
+		secrets, err := stored.GetSecrets(request)
+		if err != ErrSecretAgentNoSecrets {
+			return ConnectionSettings{settingName: secrets}, err
+		}
+	}
+
+	if a.Interactive == nil || flags&NmSecretAgentGetSecretsFlagAllowInteraction == 0 {
+		return nil, ErrSecretAgentNoSecrets
+	}
+	secrets, err := a.Interactive.GetSecrets(request)
+	select {
+	case <-cancel:
+		return nil, ErrSecretAgentAgentCanceled
+	default:
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stored != nil {
+		if err := stored.SaveSecrets(uuid, ConnectionSettings{settingName: secrets}); err != nil {
+			return nil, err
+		}
+	}
+	return ConnectionSettings{settingName: secrets}, nil
+}
+
+func (a *SecretProviderAgent) CancelGetSecrets(connectionPath dbus.ObjectPath, settingName string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	key := string(connectionPath) + "/" + settingName
+	if cancel, ok := a.pending[key]; ok {
+		close(cancel)
+		delete(a.pending, key)
+	}
+	return nil
+}
+
+func (a *SecretProviderAgent) SaveSecrets(connection ConnectionSettings, connectionPath dbus.ObjectPath) error {
+	uuid, _ := connection[SettingConnection]["uuid"].(string)
+	for name, group := range connection {
+		secrets := make(map[string]interface{})
+		for key, value := range group {
+			if isSecretSettingKey(name, key) {
+				secrets[key] = value
+			}
+		}
+		if len(secrets) == 0 {
+			continue
+		}
+		if p := a.provider(uuid, name); p != nil {
+			if err := p.SaveSecrets(uuid, ConnectionSettings{name: secrets}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (a *SecretProviderAgent) DeleteSecrets(connection ConnectionSettings, connectionPath dbus.ObjectPath) error {
+	uuid, _ := connection[SettingConnection]["uuid"].(string)
+	for name := range connection {
+		if p := a.provider(uuid, name); p != nil {
+			if err := p.DeleteSecrets(uuid); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MemorySecretProvider keeps secrets in memory. It is mostly useful for
+// tests, and as the base of FileSecretProvider.
+type MemorySecretProvider struct {
+	mu      sync.Mutex
+	secrets map[string]ConnectionSettings
+}
+
+func NewMemorySecretProvider() *MemorySecretProvider {
+	return &MemorySecretProvider{secrets: make(map[string]ConnectionSettings)}
+}
+
+// Set sets the secret keys of a setting of the connection uuid.
+func (p *MemorySecretProvider) Set(uuid, settingName string, secrets map[string]interface{}) {
+	p.SaveSecrets(uuid, ConnectionSettings{settingName: secrets})
+}
+
+// GetSecrets returns the stored secrets. As they are never new, requests with
+// NmSecretAgentGetSecretsFlagRequestNew get ErrSecretAgentNoSecrets.
+func (p *MemorySecretProvider) GetSecrets(request SecretRequest) (map[string]interface{}, error) {
+	if request.Flags&NmSecretAgentGetSecretsFlagRequestNew != 0 {
+		return nil, ErrSecretAgentNoSecrets
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	group, ok := p.secrets[request.UUID][request.SettingName]
+	if !ok || len(group) == 0 {
+		return nil, ErrSecretAgentNoSecrets
+	}
+	return copySetting(group), nil
+}
+
+func (p *MemorySecretProvider) SaveSecrets(uuid string, secrets ConnectionSettings) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.secrets == nil {
+		p.secrets = make(map[string]ConnectionSettings)
+	}
+	if p.secrets[uuid] == nil {
+		p.secrets[uuid] = make(ConnectionSettings)
+	}
+	for name, group := range secrets {
+		if p.secrets[uuid][name] == nil {
+			p.secrets[uuid][name] = make(map[string]interface{})
+		}
+		for key, value := range group {
+			p.secrets[uuid][name][key] = value
+		}
+	}
+	return nil
+}
+
+func (p *MemorySecretProvider) DeleteSecrets(uuid string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.secrets, uuid)
+	return nil
+}
+
+// SecretProviderFunc is a SecretProvider backed by a callback, for example
+// one prompting the user. It does not store secrets.
+type SecretProviderFunc func(request SecretRequest) (map[string]interface{}, error)
+
+func (f SecretProviderFunc) GetSecrets(request SecretRequest) (map[string]interface{}, error) {
+	return f(request)
+}
+
+func (f SecretProviderFunc) SaveSecrets(uuid string, secrets ConnectionSettings) error {
+	return nil
+}
+
+func (f SecretProviderFunc) DeleteSecrets(uuid string) error {
+	return nil
+}
+
+// EnvSecretProvider reads secrets from environment variables named
+// <Prefix><UUID>_<SETTING>_<KEY>, upper-cased and with all characters other
+// than letters and digits replaced by '_', for example
+// NM_SECRET_3F1A..._802_11_WIRELESS_SECURITY_PSK. VPN secrets are looked up
+// by the names given in the request hints. It does not store secrets.
+type EnvSecretProvider struct {
+	Prefix string
+}
+
+func (p EnvSecretProvider) GetSecrets(request SecretRequest) (map[string]interface{}, error) {
+	if request.Flags&NmSecretAgentGetSecretsFlagRequestNew != 0 {
+		return nil, ErrSecretAgentNoSecrets
+	}
+
+	secrets := make(map[string]interface{})
+	if request.SettingName == SettingVPN {
+		vpnSecrets := make(map[string]string)
+		for _, hint := range request.Hints {
+			if strings.Contains(hint, ":") {
+				continue
+			}
+			if v, ok := os.LookupEnv(p.variable(request.UUID, request.SettingName, hint)); ok {
+				vpnSecrets[hint] = v
+			}
+		}
+		if len(vpnSecrets) > 0 {
+			secrets["secrets"] = vpnSecrets
+		}
+	} else {
+		for _, key := range secretSettingKeys[request.SettingName] {
+			if v, ok := os.LookupEnv(p.variable(request.UUID, request.SettingName, key)); ok {
+				secrets[key] = v
+			}
+		}
+	}
+
+	if len(secrets) == 0 {
+		return nil, ErrSecretAgentNoSecrets
+	}
+	return secrets, nil
+}
+
+func (p EnvSecretProvider) variable(uuid, settingName, key string) string {
+	name := strings.ToUpper(uuid + "_" + settingName + "_" + key)
+	return p.Prefix + strings.Map(func(r rune) rune {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func (p EnvSecretProvider) SaveSecrets(uuid string, secrets ConnectionSettings) error {
+	return nil
+}
+
+func (p EnvSecretProvider) DeleteSecrets(uuid string) error {
+	return nil
+}
@@ -10,6 +10,10 @@ const (
 
 	SettingsListConnections = SettingsInterface + ".ListConnections"
 	SettingsAddConnection   = SettingsInterface + ".AddConnection"
+	SettingsSaveHostname    = SettingsInterface + ".SaveHostname"
+
+	SettingsPropertyHostname  = SettingsInterface + ".Hostname"
+	SettingsPropertyCanModify = SettingsInterface + ".CanModify"
 )
 
 type Settings interface {
@@ -17,8 +21,27 @@ type Settings interface {
 	// ListConnections gets list the saved network connections known to NetworkManager
 	ListConnections() ([]Connection, error)
 
-	// AddConnection call new connection and save it to disk.
+	// AddConnection call new connection and save it to disk. The settings are
+	// converted with EncodeSettings. Use Validate to check them first.
 	AddConnection(settings ConnectionSettings) (Connection, error)
+
+	// GetHostname gets the persistent hostname, "" if none is set.
+	GetHostname() (string, error)
+
+	// SaveHostname saves hostname as the persistent hostname. An empty
+	// hostname clears it.
+	SaveHostname(hostname string) error
+
+	// GetCanModify gets whether the settings plugins can save connections
+	// and the hostname.
+	GetCanModify() (bool, error)
+
+	// SubscribeHostname returns a channel receiving the persistent hostname
+	// each time it changes.
+	SubscribeHostname() <-chan string
+
+	// UnsubscribeHostname stops the subscription and closes its channel.
+	UnsubscribeHostname()
 }
 
 func NewSettings() (Settings, error) {
@@ -28,6 +51,9 @@ func NewSettings() (Settings, error) {
 
 type settings struct {
 	dbusBase
+
+	hostnameSub  *signalSubscription
+	hostnameChan chan string
 }
 
 func (s *settings) ListConnections() ([]Connection, error) {
@@ -50,8 +76,13 @@ func (s *settings) ListConnections() ([]Connection, error) {
 }
 
 func (s *settings) AddConnection(settings ConnectionSettings) (Connection, error) {
+	encoded, err := EncodeSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+
 	var path dbus.ObjectPath
-	err := s.call(&path, SettingsAddConnection, settings)
+	err = s.call(&path, SettingsAddConnection, encoded)
 	if err != nil {
 		return nil, err
 	}
@@ -61,3 +92,59 @@ func (s *settings) AddConnection(settings ConnectionSettings) (Connection, error
 	}
 	return con, nil
 }
+
+func (s *settings) GetHostname() (string, error) {
+	return s.getStringProperty(SettingsPropertyHostname)
+}
+
+func (s *settings) SaveHostname(hostname string) error {
+	return s.obj.Call(SettingsSaveHostname, 0, hostname).Store()
+}
+
+func (s *settings) GetCanModify() (bool, error) {
+	return s.getBoolProperty(SettingsPropertyCanModify)
+}
+
+func (s *settings) SubscribeHostname() <-chan string {
+	if s.hostnameChan != nil {
+		return s.hostnameChan
+	}
+
+	sub := s.subscribeSignals(dbusPropertiesInterface, dbusSignalPropertiesChanged)
+	hostnameChan := make(chan string, 10)
+	s.hostnameSub, s.hostnameChan = sub, hostnameChan
+
+	go func() {
+		defer close(hostnameChan)
+		for {
+			sig, ok := sub.next()
+			if !ok {
+				return
+			}
+			changed, ok := changedProperties(sig, SettingsInterface)
+			if !ok {
+				continue
+			}
+			hostname, ok := changed["Hostname"].Value().(string)
+			if !ok {
+				continue
+			}
+			select {
+			case hostnameChan <- hostname:
+			case <-sub.closed():
+				return
+			}
+		}
+	}()
+
+	return hostnameChan
+}
+
+func (s *settings) UnsubscribeHostname() {
+	if s.hostnameSub == nil {
+		return
+	}
+	s.hostnameSub.close()
+	s.hostnameSub = nil
+	s.hostnameChan = nil
+}
@@ -0,0 +1,77 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	TeamDeviceInterface = DeviceInterface + ".Team"
+
+	TeamDevicePropertyHwAddress = TeamDeviceInterface + ".HwAddress"
+	TeamDevicePropertyCarrier   = TeamDeviceInterface + ".Carrier"
+	TeamDevicePropertySlaves    = TeamDeviceInterface + ".Slaves"
+	TeamDevicePropertyConfig    = TeamDeviceInterface + ".Config"
+)
+
+type TeamDevice interface {
+	Device
+
+	// GetHwAddress gets the hardware (MAC) address of the device.
+	GetHwAddress() (string, error)
+
+	// GetCarrier gets whether the device has carrier.
+	GetCarrier() (bool, error)
+
+	// GetSlaves gets the devices enslaved to the team.
+	GetSlaves() ([]Device, error)
+
+	// GetConfig gets the JSON configuration of the teamd daemon.
+	GetConfig() (string, error)
+}
+
+func NewTeamDevice(objectPath dbus.ObjectPath) (TeamDevice, error) {
+	var d teamDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type teamDevice struct {
+	device
+}
+
+func (d *teamDevice) GetHwAddress() (string, error) {
+	return d.getStringProperty(TeamDevicePropertyHwAddress)
+}
+
+func (d *teamDevice) GetCarrier() (bool, error) {
+	return d.getBoolProperty(TeamDevicePropertyCarrier)
+}
+
+func (d *teamDevice) GetSlaves() ([]Device, error) {
+	return d.getSlaves(TeamDevicePropertySlaves)
+}
+
+func (d *teamDevice) GetConfig() (string, error) {
+	return d.getStringProperty(TeamDevicePropertyConfig)
+}
+
+func (d *teamDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["HwAddress"], err = d.GetHwAddress(); err != nil {
+		return nil, err
+	}
+	if m["Carrier"], err = d.GetCarrier(); err != nil {
+		return nil, err
+	}
+	if m["Slaves"], err = d.GetSlaves(); err != nil {
+		return nil, err
+	}
+	if m["Config"], err = d.GetConfig(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,177 @@
+package gonetworkmanager
+
+import (
+	"sync"
+	"time"
+
+	"github.com/godbus/dbus"
+)
+
+// TrafficSample is the traffic rate of a device between two refreshes of its
+// counters.
+type TrafficSample struct {
+	// Time is when the counters were refreshed.
+	Time time.Time
+
+	// Interval is the time since the previous refresh.
+	Interval time.Duration
+
+	TxBytesPerSec float64
+	RxBytesPerSec float64
+}
+
+// TrafficSampler turns the counter updates of devices into series of traffic
+// rates, keeping the samples of a sliding window.
+type TrafficSampler struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	devices map[dbus.ObjectPath]*sampledDevice
+}
+
+type sampledDevice struct {
+	stats       DeviceStatistics
+	refreshRate uint32
+
+	counters DeviceCounters
+	time     time.Time
+	samples  []TrafficSample
+}
+
+// NewTrafficSampler returns a sampler keeping the samples of the last window.
+func NewTrafficSampler(window time.Duration) *TrafficSampler {
+	return &TrafficSampler{
+		window:  window,
+		devices: make(map[dbus.ObjectPath]*sampledDevice),
+	}
+}
+
+// Add starts sampling device, setting the refresh rate of its counters to
+// interval. The previous refresh rate is restored by Remove.
+func (t *TrafficSampler) Add(device Device, interval time.Duration) error {
+	path := device.GetPath()
+
+	// The lock is held until the device is inserted so that concurrent calls
+	// do not both change the refresh rate.
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.devices[path]; ok {
+		return nil
+	}
+
+	stats, err := device.GetStatistics()
+	if err != nil {
+		return err
+	}
+	refreshRate, err := stats.GetRefreshRateMs()
+	if err != nil {
+		return err
+	}
+	if err := stats.SetRefreshRateMs(uint32(interval / time.Millisecond)); err != nil {
+		return err
+	}
+
+	sd := &sampledDevice{stats: stats, refreshRate: refreshRate}
+	t.devices[path] = sd
+
+	counters := stats.SubscribeCounters()
+	go func() {
+		for c := range counters {
+			t.update(path, sd, c, time.Now())
+		}
+	}()
+	return nil
+}
+
+func (t *TrafficSampler) update(path dbus.ObjectPath, sd *sampledDevice, c DeviceCounters, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.devices[path] != sd {
+		return
+	}
+
+	// The first update only sets the baseline, and counters going back mean
+	// the device was recreated.
+	if !sd.time.IsZero() && c.TxBytes >= sd.counters.TxBytes && c.RxBytes >= sd.counters.RxBytes {
+		interval := now.Sub(sd.time)
+		if seconds := interval.Seconds(); seconds > 0 {
+			sd.samples = append(sd.samples, TrafficSample{
+				Time:          now,
+				Interval:      interval,
+				TxBytesPerSec: float64(c.TxBytes-sd.counters.TxBytes) / seconds,
+				RxBytesPerSec: float64(c.RxBytes-sd.counters.RxBytes) / seconds,
+			})
+		}
+	}
+	sd.counters, sd.time = c, now
+	t.prune(sd, now)
+}
+
+// prune drops the samples that are older than the window.
+func (t *TrafficSampler) prune(sd *sampledDevice, now time.Time) {
+	start := now.Add(-t.window)
+	i := 0
+	for i < len(sd.samples) && sd.samples[i].Time.Before(start) {
+		i++
+	}
+	sd.samples = sd.samples[i:]
+}
+
+// Samples returns the samples of device in the window, oldest first.
+func (t *TrafficSampler) Samples(device Device) []TrafficSample {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	sd, ok := t.devices[device.GetPath()]
+	if !ok {
+		return nil
+	}
+	t.prune(sd, time.Now())
+	return append([]TrafficSample(nil), sd.samples...)
+}
+
+// Average returns the average traffic rates of device over the window.
+func (t *TrafficSampler) Average(device Device) (txBytesPerSec, rxBytesPerSec float64) {
+	var total time.Duration
+	for _, s := range t.Samples(device) {
+		seconds := s.Interval.Seconds()
+		txBytesPerSec += s.TxBytesPerSec * seconds
+		rxBytesPerSec += s.RxBytesPerSec * seconds
+		total += s.Interval
+	}
+	if total == 0 {
+		return 0, 0
+	}
+	return txBytesPerSec / total.Seconds(), rxBytesPerSec / total.Seconds()
+}
+
+// Remove stops sampling device and restores the refresh rate of its
+// counters.
+func (t *TrafficSampler) Remove(device Device) error {
+	t.mu.Lock()
+	sd, ok := t.devices[device.GetPath()]
+	delete(t.devices, device.GetPath())
+	t.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	sd.stats.UnsubscribeCounters()
+	return sd.stats.SetRefreshRateMs(sd.refreshRate)
+}
+
+// Close stops sampling all devices.
+func (t *TrafficSampler) Close() error {
+	t.mu.Lock()
+	devices := t.devices
+	t.devices = make(map[dbus.ObjectPath]*sampledDevice)
+	t.mu.Unlock()
+
+	var err error
+	for _, sd := range devices {
+		sd.stats.UnsubscribeCounters()
+		if e := sd.stats.SetRefreshRateMs(sd.refreshRate); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
@@ -0,0 +1,150 @@
+package gonetworkmanager
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus"
+)
+
+type fakeStatistics struct {
+	DeviceStatistics
+
+	mu            sync.Mutex
+	rate          uint32
+	subscriptions int
+}
+
+func (s *fakeStatistics) GetRefreshRateMs() (uint32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rate, nil
+}
+
+func (s *fakeStatistics) SetRefreshRateMs(rate uint32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rate = rate
+	return nil
+}
+
+func (s *fakeStatistics) SubscribeCounters() <-chan DeviceCounters {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.subscriptions++
+	return make(chan DeviceCounters)
+}
+
+func (s *fakeStatistics) UnsubscribeCounters() {}
+
+type fakeStatisticsDevice struct {
+	Device
+	stats *fakeStatistics
+}
+
+func (d *fakeStatisticsDevice) GetPath() dbus.ObjectPath {
+	return "/org/freedesktop/NetworkManager/Devices/1"
+}
+
+func (d *fakeStatisticsDevice) GetStatistics() (DeviceStatistics, error) {
+	return d.stats, nil
+}
+
+func TestTrafficSamplerUpdate(t *testing.T) {
+	const path = dbus.ObjectPath("/org/freedesktop/NetworkManager/Devices/1")
+	start := time.Now()
+	at := func(seconds int) time.Time { return start.Add(time.Duration(seconds) * time.Second) }
+
+	tests := []struct {
+		name     string
+		updates  []DeviceCounters
+		times    []time.Time
+		tx, rx   []float64
+		interval time.Duration
+	}{
+		{
+			name:    "baseline only",
+			updates: []DeviceCounters{{1000, 2000}},
+			times:   []time.Time{at(0)},
+		},
+		{
+			name:    "rates",
+			updates: []DeviceCounters{{1000, 2000}, {3000, 2000}, {4000, 6000}},
+			times:   []time.Time{at(0), at(2), at(4)},
+			tx:      []float64{1000, 500},
+			rx:      []float64{0, 2000},
+		},
+		{
+			name:    "counters reset",
+			updates: []DeviceCounters{{1000, 2000}, {10, 20}, {110, 420}},
+			times:   []time.Time{at(0), at(1), at(2)},
+			tx:      []float64{100},
+			rx:      []float64{400},
+		},
+		{
+			name:    "samples older than the window",
+			updates: []DeviceCounters{{0, 0}, {100, 100}, {200, 200}, {300, 300}},
+			times:   []time.Time{at(0), at(10), at(20), at(30)},
+			tx:      []float64{10, 10},
+			rx:      []float64{10, 10},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := NewTrafficSampler(15 * time.Second)
+			sd := &sampledDevice{}
+			ts.devices[path] = sd
+			for i, c := range tt.updates {
+				ts.update(path, sd, c, tt.times[i])
+			}
+
+			if len(sd.samples) != len(tt.tx) {
+				t.Fatalf("got %d samples, want %d: %+v", len(sd.samples), len(tt.tx), sd.samples)
+			}
+			for i, s := range sd.samples {
+				if s.TxBytesPerSec != tt.tx[i] || s.RxBytesPerSec != tt.rx[i] {
+					t.Errorf("sample %d: got %v/%v, want %v/%v", i, s.TxBytesPerSec, s.RxBytesPerSec, tt.tx[i], tt.rx[i])
+				}
+			}
+		})
+	}
+}
+
+func TestTrafficSamplerUpdateRemoved(t *testing.T) {
+	ts := NewTrafficSampler(time.Minute)
+	sd := &sampledDevice{}
+	ts.update("/removed", sd, DeviceCounters{1, 1}, time.Now())
+	if !sd.time.IsZero() {
+		t.Error("updated a device that is not sampled")
+	}
+}
+
+func TestTrafficSamplerAddConcurrent(t *testing.T) {
+	stats := &fakeStatistics{rate: 5000}
+	device := &fakeStatisticsDevice{stats: stats}
+	ts := NewTrafficSampler(time.Minute)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := ts.Add(device, time.Second); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if stats.subscriptions != 1 {
+		t.Errorf("subscribed %d times, want once", stats.subscriptions)
+	}
+	if err := ts.Remove(device); err != nil {
+		t.Fatal(err)
+	}
+	if stats.rate != 5000 {
+		t.Errorf("restored refresh rate %d, want 5000", stats.rate)
+	}
+}
@@ -0,0 +1,101 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	TunDeviceInterface = DeviceInterface + ".Tun"
+
+	TunDevicePropertyOwner      = TunDeviceInterface + ".Owner"
+	TunDevicePropertyGroup      = TunDeviceInterface + ".Group"
+	TunDevicePropertyMode       = TunDeviceInterface + ".Mode"
+	TunDevicePropertyNoPi       = TunDeviceInterface + ".NoPi"
+	TunDevicePropertyVnetHdr    = TunDeviceInterface + ".VnetHdr"
+	TunDevicePropertyMultiQueue = TunDeviceInterface + ".MultiQueue"
+	TunDevicePropertyHwAddress  = TunDeviceInterface + ".HwAddress"
+)
+
+type TunDevice interface {
+	Device
+
+	// GetOwner gets the uid of the tunnel owner, or -1 if it has no owner.
+	GetOwner() (int64, error)
+
+	// GetGroup gets the gid of the tunnel group, or -1 if it has no group.
+	GetGroup() (int64, error)
+
+	// GetMode gets the tunnel mode, either "tun" or "tap".
+	GetMode() (string, error)
+
+	// GetNoPi gets whether the IFF_NO_PI flag is set.
+	GetNoPi() (bool, error)
+
+	// GetVnetHdr gets whether the IFF_VNET_HDR flag is set.
+	GetVnetHdr() (bool, error)
+
+	// GetMultiQueue gets whether the IFF_MULTI_QUEUE flag is set.
+	GetMultiQueue() (bool, error)
+
+	// GetHwAddress gets the hardware address of the device.
+	GetHwAddress() (string, error)
+}
+
+func NewTunDevice(objectPath dbus.ObjectPath) (TunDevice, error) {
+	var d tunDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type tunDevice struct {
+	device
+}
+
+func (d *tunDevice) GetOwner() (int64, error) {
+	return d.getInt64Property(TunDevicePropertyOwner)
+}
+
+func (d *tunDevice) GetGroup() (int64, error) {
+	return d.getInt64Property(TunDevicePropertyGroup)
+}
+
+func (d *tunDevice) GetMode() (string, error) {
+	return d.getStringProperty(TunDevicePropertyMode)
+}
+
+func (d *tunDevice) GetNoPi() (bool, error) {
+	return d.getBoolProperty(TunDevicePropertyNoPi)
+}
+
+func (d *tunDevice) GetVnetHdr() (bool, error) {
+	return d.getBoolProperty(TunDevicePropertyVnetHdr)
+}
+
+func (d *tunDevice) GetMultiQueue() (bool, error) {
+	return d.getBoolProperty(TunDevicePropertyMultiQueue)
+}
+
+func (d *tunDevice) GetHwAddress() (string, error) {
+	return d.getStringProperty(TunDevicePropertyHwAddress)
+}
+
+func (d *tunDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["Owner"], err = d.GetOwner(); err != nil {
+		return nil, err
+	}
+	if m["Group"], err = d.GetGroup(); err != nil {
+		return nil, err
+	}
+	if m["Mode"], err = d.GetMode(); err != nil {
+		return nil, err
+	}
+	if m["HwAddress"], err = d.GetHwAddress(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
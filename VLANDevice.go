@@ -0,0 +1,85 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	VLANDeviceInterface = DeviceInterface + ".Vlan"
+
+	VLANDevicePropertyHwAddress = VLANDeviceInterface + ".HwAddress"
+	VLANDevicePropertyCarrier   = VLANDeviceInterface + ".Carrier"
+	VLANDevicePropertyParent    = VLANDeviceInterface + ".Parent"
+	VLANDevicePropertyVlanId    = VLANDeviceInterface + ".VlanId"
+)
+
+type VLANDevice interface {
+	Device
+
+	// GetHwAddress gets the hardware (MAC) address of the device.
+	GetHwAddress() (string, error)
+
+	// GetCarrier gets whether the device has carrier.
+	GetCarrier() (bool, error)
+
+	// GetParent gets the device the VLAN is on, or nil if it has none.
+	GetParent() (Device, error)
+
+	// GetVlanId gets the VLAN ID of the device.
+	GetVlanId() (uint32, error)
+}
+
+func NewVLANDevice(objectPath dbus.ObjectPath) (VLANDevice, error) {
+	var d vlanDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type vlanDevice struct {
+	device
+}
+
+func (d *vlanDevice) GetHwAddress() (string, error) {
+	return d.getStringProperty(VLANDevicePropertyHwAddress)
+}
+
+func (d *vlanDevice) GetCarrier() (bool, error) {
+	return d.getBoolProperty(VLANDevicePropertyCarrier)
+}
+
+func (d *vlanDevice) GetParent() (Device, error) {
+	path, err := d.getObjectProperty(VLANDevicePropertyParent)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+	return DeviceFactory(path)
+}
+
+func (d *vlanDevice) GetVlanId() (uint32, error) {
+	return d.getUint32Property(VLANDevicePropertyVlanId)
+}
+
+func (d *vlanDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["HwAddress"], err = d.GetHwAddress(); err != nil {
+		return nil, err
+	}
+	if m["Carrier"], err = d.GetCarrier(); err != nil {
+		return nil, err
+	}
+	parent, err := d.GetParent()
+	if err != nil {
+		return nil, err
+	}
+	if parent != nil {
+		m["Parent"] = parent.GetPath()
+	}
+	if m["VlanId"], err = d.GetVlanId(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
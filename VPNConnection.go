@@ -0,0 +1,101 @@
+package gonetworkmanager
+
+import (
+	"github.com/godbus/dbus"
+)
+
+const (
+	VPNConnectionInterface = NetworkManagerInterface + ".VPN.Connection"
+
+	VPNConnectionPropertyVpnState = VPNConnectionInterface + ".VpnState"
+	VPNConnectionPropertyBanner   = VPNConnectionInterface + ".Banner"
+
+	VPNConnectionSignalVpnStateChanged = "VpnStateChanged"
+)
+
+// VPNStateChange is a VpnStateChanged signal of a VPN connection.
+type VPNStateChange struct {
+	State  NmVpnConnectionState
+	Reason NmVpnConnectionStateReason
+}
+
+// VPNConnection is an active VPN connection, as returned by
+// ActiveConnectionFactory when GetVPN is true.
+type VPNConnection interface {
+	ActiveConnection
+
+	// GetVpnState gets the state of the VPN plugin.
+	GetVpnState() (NmVpnConnectionState, error)
+
+	// GetBanner gets the login banner of the VPN connection.
+	GetBanner() (string, error)
+
+	// SubscribeVpnState returns a channel receiving the VpnStateChanged
+	// signals of this connection.
+	SubscribeVpnState() <-chan VPNStateChange
+
+	// UnsubscribeVpnState stops the subscription and closes its channel.
+	UnsubscribeVpnState()
+}
+
+func NewVPNConnection(objectPath dbus.ObjectPath) (VPNConnection, error) {
+	var c vpnConnection
+	return &c, c.init(NetworkManagerInterface, objectPath)
+}
+
+type vpnConnection struct {
+	activeConnection
+
+	stateSub  *signalSubscription
+	stateChan chan VPNStateChange
+}
+
+func (c *vpnConnection) GetVpnState() (NmVpnConnectionState, error) {
+	v, err := c.getUint32Property(VPNConnectionPropertyVpnState)
+	return NmVpnConnectionState(v), err
+}
+
+func (c *vpnConnection) GetBanner() (string, error) {
+	return c.getStringProperty(VPNConnectionPropertyBanner)
+}
+
+func (c *vpnConnection) SubscribeVpnState() <-chan VPNStateChange {
+	if c.stateChan != nil {
+		return c.stateChan
+	}
+
+	sub := c.subscribeSignals(VPNConnectionInterface, VPNConnectionSignalVpnStateChanged)
+	stateChan := make(chan VPNStateChange, 10)
+	c.stateSub, c.stateChan = sub, stateChan
+
+	go func() {
+		defer close(stateChan)
+		for {
+			sig, ok := sub.next()
+			if !ok {
+				return
+			}
+			if len(sig.Body) != 2 {
+				continue
+			}
+			state, _ := sig.Body[0].(uint32)
+			reason, _ := sig.Body[1].(uint32)
+			select {
+			case stateChan <- VPNStateChange{NmVpnConnectionState(state), NmVpnConnectionStateReason(reason)}:
+			case <-sub.closed():
+				return
+			}
+		}
+	}()
+
+	return stateChan
+}
+
+func (c *vpnConnection) UnsubscribeVpnState() {
+	if c.stateSub == nil {
+		return
+	}
+	c.stateSub.close()
+	c.stateSub = nil
+	c.stateChan = nil
+}
@@ -0,0 +1,206 @@
+package gonetworkmanager
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Service types of the VPN plugins shipped with NetworkManager.
+const (
+	VPNServiceTypeOpenVPN = NetworkManagerInterface + ".openvpn"
+	VPNServiceTypeVPNC    = NetworkManagerInterface + ".vpnc"
+)
+
+// Values of the <secret>-flags keys of VPN data, see NmSettingSecretFlags in
+// the NetworkManager documentation.
+const (
+	vpnSecretFlagNone        = "0"
+	vpnSecretFlagAgentOwned  = "1"
+	vpnSecretFlagNotRequired = "4"
+)
+
+// NewVPNConnectionSettings returns a profile for the VPN plugin serviceType
+// with the given plugin data and secrets. It gets a new random UUID and uses
+// automatic IPv4 and IPv6 configuration.
+func NewVPNConnectionSettings(id, serviceType string, data, secrets map[string]string) ConnectionSettings {
+	settings := newConnectionSettings(id, SettingVPN)
+	settings[SettingVPN] = map[string]interface{}{
+		"service-type": serviceType,
+		"data":         data,
+	}
+	if len(secrets) > 0 {
+		settings[SettingVPN]["secrets"] = secrets
+	}
+	return settings
+}
+
+// newConnectionSettings returns the connection, ipv4 and ipv6 settings common
+// to the profiles created by the New*ConnectionSettings builders.
+func newConnectionSettings(id, connectionType string) ConnectionSettings {
+	return ConnectionSettings{
+		SettingConnection: {
+			"id":   id,
+			"uuid": newUUID(),
+			"type": connectionType,
+		},
+		SettingIP4Config: {"method": "auto"},
+		SettingIP6Config: {"method": "auto"},
+	}
+}
+
+// OpenVPNOptions are the common options of an OpenVPN profile.
+type OpenVPNOptions struct {
+	// Remote is the server, as "host", "host:port" or a comma separated list.
+	Remote string
+
+	// ConnectionType is one of "tls", "password", "password-tls" or
+	// "static-key". If empty, it is derived from the other options.
+	ConnectionType string
+
+	CA   string
+	Cert string
+	Key  string
+
+	// KeyPassword decrypts Key.
+	KeyPassword string
+
+	Username string
+	Password string
+
+	// StaticKey is used with the "static-key" connection type.
+	StaticKey string
+
+	Port uint32
+	TCP  bool
+
+	// Data holds further plugin data keys, for example "cipher" or
+	// "comp-lzo". They override the keys set from the options above.
+	Data map[string]string
+}
+
+// NewOpenVPNConnectionSettings returns an OpenVPN profile. Passwords given in
+// the options are stored with the profile. Leave them empty to have them
+// requested from a secret agent instead.
+func NewOpenVPNConnectionSettings(id string, opts OpenVPNOptions) (ConnectionSettings, error) {
+	if opts.Remote == "" {
+		return nil, fmt.Errorf("openvpn: remote is required")
+	}
+
+	connectionType := opts.ConnectionType
+	if connectionType == "" {
+		switch {
+		case opts.StaticKey != "":
+			connectionType = "static-key"
+		case opts.Username != "" && opts.Cert != "":
+			connectionType = "password-tls"
+		case opts.Username != "":
+			connectionType = "password"
+		default:
+			connectionType = "tls"
+		}
+	}
+
+	data := map[string]string{
+		"remote":          opts.Remote,
+		"connection-type": connectionType,
+	}
+	secrets := make(map[string]string)
+	set := func(key, value string) {
+		if value != "" {
+			data[key] = value
+		}
+	}
+
+	set("ca", opts.CA)
+	set("cert", opts.Cert)
+	set("key", opts.Key)
+	set("static-key", opts.StaticKey)
+	set("username", opts.Username)
+	if opts.Port != 0 {
+		data["port"] = fmt.Sprint(opts.Port)
+	}
+	if opts.TCP {
+		data["proto-tcp"] = "yes"
+	}
+
+	if strings.HasPrefix(connectionType, "password") {
+		data["password-flags"] = vpnSecretAgentFlags(opts.Password)
+		if opts.Password != "" {
+			secrets["password"] = opts.Password
+		}
+	}
+	if opts.Key != "" && connectionType != "password" {
+		if opts.KeyPassword != "" {
+			data["cert-pass-flags"] = vpnSecretFlagNone
+			secrets["cert-pass"] = opts.KeyPassword
+		} else {
+			data["cert-pass-flags"] = vpnSecretFlagNotRequired
+		}
+	}
+
+	for k, v := range opts.Data {
+		data[k] = v
+	}
+
+	return NewVPNConnectionSettings(id, VPNServiceTypeOpenVPN, data, secrets), nil
+}
+
+// VPNCOptions are the options of a Cisco compatible IPsec (vpnc) profile.
+type VPNCOptions struct {
+	Gateway string
+
+	// GroupName and GroupPassword are the IPsec ID and secret.
+	GroupName     string
+	GroupPassword string
+
+	// Username and Password are used for Xauth.
+	Username string
+	Password string
+
+	// Data holds further plugin data keys, for example "Vendor" or
+	// "NAT Traversal Mode".
+	Data map[string]string
+}
+
+// NewVPNCConnectionSettings returns a vpnc profile. As with
+// NewOpenVPNConnectionSettings, empty passwords are left to a secret agent.
+func NewVPNCConnectionSettings(id string, opts VPNCOptions) (ConnectionSettings, error) {
+	if opts.Gateway == "" {
+		return nil, fmt.Errorf("vpnc: gateway is required")
+	}
+	if opts.GroupName == "" {
+		return nil, fmt.Errorf("vpnc: group name is required")
+	}
+
+	data := map[string]string{
+		"IPSec gateway":        opts.Gateway,
+		"IPSec ID":             opts.GroupName,
+		"IPSec secret-flags":   vpnSecretAgentFlags(opts.GroupPassword),
+		"Xauth password-flags": vpnSecretAgentFlags(opts.Password),
+	}
+	if opts.Username != "" {
+		data["Xauth username"] = opts.Username
+	}
+	secrets := make(map[string]string)
+	if opts.GroupPassword != "" {
+		secrets["IPSec secret"] = opts.GroupPassword
+	}
+	if opts.Password != "" {
+		secrets["Xauth password"] = opts.Password
+	}
+
+	for k, v := range opts.Data {
+		data[k] = v
+	}
+
+	return NewVPNConnectionSettings(id, VPNServiceTypeVPNC, data, secrets), nil
+}
+
+// vpnSecretAgentFlags returns the flags of a VPN secret: stored with the
+// profile when given, owned by a secret agent otherwise.
+func vpnSecretAgentFlags(secret string) string {
+	if secret != "" {
+		return vpnSecretFlagNone
+	}
+	return vpnSecretFlagAgentOwned
+}
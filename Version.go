@@ -0,0 +1,66 @@
+package gonetworkmanager
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NmVersion is a parsed NetworkManager version, for enabling features only
+// where the running daemon supports them.
+type NmVersion struct {
+	Major, Minor, Micro int
+
+	// Extra is the part of the version string following the micro version,
+	// such as "-dev" or "-1.fc30". It is ignored when comparing versions.
+	Extra string
+}
+
+// ParseNmVersion parses a version string such as "1.22.10".
+func ParseNmVersion(s string) (NmVersion, error) {
+	var v NmVersion
+	rest := s
+	fields := []*int{&v.Major, &v.Minor, &v.Micro}
+	for i, field := range fields {
+		end := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if end < 0 {
+			end = len(rest)
+		}
+		n, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return NmVersion{}, fmt.Errorf("invalid version %q", s)
+		}
+		*field = n
+		rest = rest[end:]
+		if i < len(fields)-1 {
+			if !strings.HasPrefix(rest, ".") {
+				break
+			}
+			rest = rest[1:]
+		}
+	}
+	v.Extra = rest
+	return v, nil
+}
+
+// Compare returns -1, 0 or 1 if v is older than, equal to or newer than w.
+func (v NmVersion) Compare(w NmVersion) int {
+	for _, d := range []int{v.Major - w.Major, v.Minor - w.Minor, v.Micro - w.Micro} {
+		if d < 0 {
+			return -1
+		}
+		if d > 0 {
+			return 1
+		}
+	}
+	return 0
+}
+
+// AtLeast reports whether v is major.minor.micro or newer.
+func (v NmVersion) AtLeast(major, minor, micro int) bool {
+	return v.Compare(NmVersion{Major: major, Minor: minor, Micro: micro}) >= 0
+}
+
+func (v NmVersion) String() string {
+	return fmt.Sprintf("%d.%d.%d%s", v.Major, v.Minor, v.Micro, v.Extra)
+}
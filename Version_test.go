@@ -0,0 +1,56 @@
+package gonetworkmanager
+
+import "testing"
+
+func TestParseNmVersion(t *testing.T) {
+	tests := []struct {
+		in   string
+		want NmVersion
+		err  bool
+	}{
+		{in: "1.22.10", want: NmVersion{1, 22, 10, ""}},
+		{in: "1.22.10-1.fc32", want: NmVersion{1, 22, 10, "-1.fc32"}},
+		{in: "1.31.2-dev", want: NmVersion{1, 31, 2, "-dev"}},
+		{in: "1.20", want: NmVersion{1, 20, 0, ""}},
+		{in: "1", want: NmVersion{1, 0, 0, ""}},
+		{in: "1.8.4.0", want: NmVersion{1, 8, 4, ".0"}},
+		{in: "", err: true},
+		{in: "v1.2.3", err: true},
+		{in: "1..2", err: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseNmVersion(tt.in)
+		if (err != nil) != tt.err {
+			t.Errorf("ParseNmVersion(%q): error %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseNmVersion(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNmVersionCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.22.10", "1.22.10", 0},
+		{"1.22.10-dev", "1.22.10", 0},
+		{"1.22.9", "1.22.10", -1},
+		{"1.30.0", "1.22.10", 1},
+		{"2.0.0", "1.99.99", 1},
+	}
+	for _, tt := range tests {
+		a, _ := ParseNmVersion(tt.a)
+		b, _ := ParseNmVersion(tt.b)
+		if got := a.Compare(b); got != tt.want {
+			t.Errorf("%s.Compare(%s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+
+	v, _ := ParseNmVersion("1.22.10")
+	if !v.AtLeast(1, 22, 0) || v.AtLeast(1, 24, 0) {
+		t.Errorf("%s: wrong AtLeast result", v)
+	}
+}
@@ -0,0 +1,52 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	VethDeviceInterface = DeviceInterface + ".Veth"
+
+	VethDevicePropertyPeer = VethDeviceInterface + ".Peer"
+)
+
+type VethDevice interface {
+	Device
+
+	// GetPeer gets the other end of the veth pair, or nil if it is unknown.
+	GetPeer() (Device, error)
+}
+
+func NewVethDevice(objectPath dbus.ObjectPath) (VethDevice, error) {
+	var d vethDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type vethDevice struct {
+	device
+}
+
+func (d *vethDevice) GetPeer() (Device, error) {
+	path, err := d.getObjectProperty(VethDevicePropertyPeer)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+	return DeviceFactory(path)
+}
+
+func (d *vethDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	peer, err := d.GetPeer()
+	if err != nil {
+		return nil, err
+	}
+	if peer != nil {
+		m["Peer"] = peer.GetPath()
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,179 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BondOptions are the options of a bond master profile.
+type BondOptions struct {
+	InterfaceName string
+
+	// Mode is the bonding mode, such as "active-backup" or "802.3ad". It
+	// defaults to "balance-rr".
+	Mode string
+
+	// MIIMon is the link monitoring interval in milliseconds, 100 if 0.
+	MIIMon uint32
+
+	// Options holds further bonding options, for example "primary" or
+	// "xmit_hash_policy". They override the ones set from the fields above.
+	Options map[string]string
+}
+
+// NewBondConnectionSettings returns a bond master profile. Add a slave profile
+// made by NewSlaveConnectionSettings for each of its devices.
+func NewBondConnectionSettings(id string, opts BondOptions) (ConnectionSettings, error) {
+	mode := opts.Mode
+	if mode == "" {
+		mode = "balance-rr"
+	}
+	miimon := opts.MIIMon
+	if miimon == 0 {
+		miimon = 100
+	}
+
+	options := map[string]string{
+		"mode":   mode,
+		"miimon": fmt.Sprint(miimon),
+	}
+	for k, v := range opts.Options {
+		options[k] = v
+	}
+
+	settings := newConnectionSettings(id, SettingBond)
+	settings[SettingConnection]["interface-name"] = opts.InterfaceName
+	settings[SettingBond] = map[string]interface{}{
+		"options": options,
+	}
+	return checkedSettings(settings)
+}
+
+// BridgeOptions are the options of a bridge master profile.
+type BridgeOptions struct {
+	InterfaceName string
+
+	// STP enables the spanning tree protocol.
+	STP bool
+
+	// Priority is the STP priority of the bridge, 32768 if 0.
+	Priority uint32
+
+	// ForwardDelay is the STP forwarding delay in seconds, 15 if 0.
+	ForwardDelay uint32
+}
+
+// NewBridgeConnectionSettings returns a bridge master profile. Add a slave
+// profile made by NewSlaveConnectionSettings for each of its ports.
+func NewBridgeConnectionSettings(id string, opts BridgeOptions) (ConnectionSettings, error) {
+	bridge := map[string]interface{}{
+		"stp": opts.STP,
+	}
+	if opts.Priority != 0 {
+		bridge["priority"] = opts.Priority
+	}
+	if opts.ForwardDelay != 0 {
+		bridge["forward-delay"] = opts.ForwardDelay
+	}
+
+	settings := newConnectionSettings(id, SettingBridge)
+	settings[SettingConnection]["interface-name"] = opts.InterfaceName
+	settings[SettingBridge] = bridge
+	return checkedSettings(settings)
+}
+
+// TeamOptions are the options of a team master profile.
+type TeamOptions struct {
+	InterfaceName string
+
+	// Runner is the teamd runner, such as "activebackup" or "lacp". It is
+	// ignored if Config is set.
+	Runner string
+
+	// Config is the complete JSON configuration passed to teamd.
+	Config string
+}
+
+// NewTeamConnectionSettings returns a team master profile. Add a slave profile
+// made by NewSlaveConnectionSettings for each of its devices.
+func NewTeamConnectionSettings(id string, opts TeamOptions) (ConnectionSettings, error) {
+	config := opts.Config
+	if config == "" && opts.Runner != "" {
+		b, err := json.Marshal(map[string]interface{}{
+			"runner": map[string]string{"name": opts.Runner},
+		})
+		if err != nil {
+			return nil, err
+		}
+		config = string(b)
+	}
+
+	settings := newConnectionSettings(id, SettingTeam)
+	settings[SettingConnection]["interface-name"] = opts.InterfaceName
+	settings[SettingTeam] = map[string]interface{}{}
+	if config != "" {
+		settings[SettingTeam]["config"] = config
+	}
+	return checkedSettings(settings)
+}
+
+// VLANOptions are the options of a VLAN profile.
+type VLANOptions struct {
+	// InterfaceName is the name of the VLAN interface. If empty,
+	// NetworkManager names it after the parent and ID, such as "eth0.10".
+	InterfaceName string
+
+	// Parent is the interface name or the connection UUID of the parent
+	// device.
+	Parent string
+
+	ID uint32
+}
+
+// NewVLANConnectionSettings returns a VLAN profile with automatic IP
+// configuration.
+func NewVLANConnectionSettings(id string, opts VLANOptions) (ConnectionSettings, error) {
+	settings := newConnectionSettings(id, SettingVLAN)
+	if opts.InterfaceName != "" {
+		settings[SettingConnection]["interface-name"] = opts.InterfaceName
+	}
+	settings[SettingVLAN] = map[string]interface{}{
+		"parent": opts.Parent,
+		"id":     opts.ID,
+	}
+	return checkedSettings(settings)
+}
+
+// NewSlaveConnectionSettings returns an Ethernet profile enslaving the device
+// interfaceName to master, the interface name or connection UUID of a bond,
+// bridge or team. slaveType is the type of the master: SettingBond,
+// SettingBridge or SettingTeam. Slaves have no IP configuration of their own.
+func NewSlaveConnectionSettings(id, interfaceName, master, slaveType string) (ConnectionSettings, error) {
+	settings := ConnectionSettings{
+		SettingConnection: {
+			"id":             id,
+			"uuid":           newUUID(),
+			"type":           SettingWired,
+			"interface-name": interfaceName,
+			"master":         master,
+			"slave-type":     slaveType,
+		},
+		SettingWired: {},
+	}
+	switch slaveType {
+	case SettingBridge:
+		settings[SettingBridgePort] = map[string]interface{}{}
+	case SettingTeam:
+		settings[SettingTeamPort] = map[string]interface{}{}
+	}
+	return checkedSettings(settings)
+}
+
+// checkedSettings validates the settings made by a builder, and returns them
+// only if they are valid.
+func checkedSettings(settings ConnectionSettings) (ConnectionSettings, error) {
+	if errs := Validate(settings); len(errs) > 0 {
+		return nil, errs
+	}
+	return settings, nil
+}
@@ -0,0 +1,44 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	VrfDeviceInterface = DeviceInterface + ".Vrf"
+
+	VrfDevicePropertyTable = VrfDeviceInterface + ".Table"
+)
+
+type VrfDevice interface {
+	Device
+
+	// GetTable gets the routing table of the VRF.
+	GetTable() (uint32, error)
+}
+
+func NewVrfDevice(objectPath dbus.ObjectPath) (VrfDevice, error) {
+	var d vrfDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type vrfDevice struct {
+	device
+}
+
+func (d *vrfDevice) GetTable() (uint32, error) {
+	return d.getUint32Property(VrfDevicePropertyTable)
+}
+
+func (d *vrfDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["Table"], err = d.GetTable(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,148 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	VxlanDeviceInterface = DeviceInterface + ".Vxlan"
+
+	VxlanDevicePropertyParent     = VxlanDeviceInterface + ".Parent"
+	VxlanDevicePropertyId         = VxlanDeviceInterface + ".Id"
+	VxlanDevicePropertyGroup      = VxlanDeviceInterface + ".Group"
+	VxlanDevicePropertyLocal      = VxlanDeviceInterface + ".Local"
+	VxlanDevicePropertyTos        = VxlanDeviceInterface + ".Tos"
+	VxlanDevicePropertyTtl        = VxlanDeviceInterface + ".Ttl"
+	VxlanDevicePropertyLearning   = VxlanDeviceInterface + ".Learning"
+	VxlanDevicePropertyAgeing     = VxlanDeviceInterface + ".Ageing"
+	VxlanDevicePropertyLimit      = VxlanDeviceInterface + ".Limit"
+	VxlanDevicePropertyDstPort    = VxlanDeviceInterface + ".DstPort"
+	VxlanDevicePropertySrcPortMin = VxlanDeviceInterface + ".SrcPortMin"
+	VxlanDevicePropertySrcPortMax = VxlanDeviceInterface + ".SrcPortMax"
+)
+
+type VxlanDevice interface {
+	Device
+
+	// GetParent gets the device the VXLAN traffic is sent through, or nil if
+	// it is not bound to a device.
+	GetParent() (Device, error)
+
+	// GetId gets the VXLAN network identifier (VNI).
+	GetId() (uint32, error)
+
+	// GetGroup gets the multicast group or unicast remote address of the
+	// tunnel.
+	GetGroup() (string, error)
+
+	// GetLocal gets the local address packets are sent from.
+	GetLocal() (string, error)
+
+	// GetTos gets the type of service of outgoing packets.
+	GetTos() (uint8, error)
+
+	// GetTtl gets the TTL of outgoing packets.
+	GetTtl() (uint8, error)
+
+	// GetLearning gets whether unknown source addresses are learned into the
+	// forwarding database.
+	GetLearning() (bool, error)
+
+	// GetAgeing gets the lifetime of forwarding database entries in seconds.
+	GetAgeing() (uint32, error)
+
+	// GetLimit gets the maximum number of forwarding database entries.
+	GetLimit() (uint32, error)
+
+	// GetDstPort gets the UDP destination port.
+	GetDstPort() (uint16, error)
+
+	// GetSrcPortMin gets the lowest UDP source port.
+	GetSrcPortMin() (uint16, error)
+
+	// GetSrcPortMax gets the highest UDP source port.
+	GetSrcPortMax() (uint16, error)
+}
+
+func NewVxlanDevice(objectPath dbus.ObjectPath) (VxlanDevice, error) {
+	var d vxlanDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type vxlanDevice struct {
+	device
+}
+
+func (d *vxlanDevice) GetParent() (Device, error) {
+	path, err := d.getObjectProperty(VxlanDevicePropertyParent)
+	if err != nil || path == "/" {
+		return nil, err
+	}
+	return DeviceFactory(path)
+}
+
+func (d *vxlanDevice) GetId() (uint32, error) {
+	return d.getUint32Property(VxlanDevicePropertyId)
+}
+
+func (d *vxlanDevice) GetGroup() (string, error) {
+	return d.getStringProperty(VxlanDevicePropertyGroup)
+}
+
+func (d *vxlanDevice) GetLocal() (string, error) {
+	return d.getStringProperty(VxlanDevicePropertyLocal)
+}
+
+func (d *vxlanDevice) GetTos() (uint8, error) {
+	return d.getUint8Property(VxlanDevicePropertyTos)
+}
+
+func (d *vxlanDevice) GetTtl() (uint8, error) {
+	return d.getUint8Property(VxlanDevicePropertyTtl)
+}
+
+func (d *vxlanDevice) GetLearning() (bool, error) {
+	return d.getBoolProperty(VxlanDevicePropertyLearning)
+}
+
+func (d *vxlanDevice) GetAgeing() (uint32, error) {
+	return d.getUint32Property(VxlanDevicePropertyAgeing)
+}
+
+func (d *vxlanDevice) GetLimit() (uint32, error) {
+	return d.getUint32Property(VxlanDevicePropertyLimit)
+}
+
+func (d *vxlanDevice) GetDstPort() (uint16, error) {
+	return d.getUint16Property(VxlanDevicePropertyDstPort)
+}
+
+func (d *vxlanDevice) GetSrcPortMin() (uint16, error) {
+	return d.getUint16Property(VxlanDevicePropertySrcPortMin)
+}
+
+func (d *vxlanDevice) GetSrcPortMax() (uint16, error) {
+	return d.getUint16Property(VxlanDevicePropertySrcPortMax)
+}
+
+func (d *vxlanDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["Id"], err = d.GetId(); err != nil {
+		return nil, err
+	}
+	if m["Group"], err = d.GetGroup(); err != nil {
+		return nil, err
+	}
+	if m["Local"], err = d.GetLocal(); err != nil {
+		return nil, err
+	}
+	if m["DstPort"], err = d.GetDstPort(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
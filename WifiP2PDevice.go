@@ -0,0 +1,159 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	WifiP2PDeviceInterface = DeviceInterface + ".WifiP2P"
+
+	WifiP2PDeviceStartFind = WifiP2PDeviceInterface + ".StartFind"
+	WifiP2PDeviceStopFind  = WifiP2PDeviceInterface + ".StopFind"
+
+	WifiP2PDevicePropertyHwAddress = WifiP2PDeviceInterface + ".HwAddress"
+	WifiP2PDevicePropertyPeers     = WifiP2PDeviceInterface + ".Peers"
+
+	WifiP2PDeviceSignalPeerAdded   = "PeerAdded"
+	WifiP2PDeviceSignalPeerRemoved = "PeerRemoved"
+)
+
+// WifiP2PPeerEvent is a PeerAdded or PeerRemoved signal of a WifiP2PDevice.
+// The properties of a removed peer can no longer be read.
+type WifiP2PPeerEvent struct {
+	Removed bool
+	Peer    WifiP2PPeer
+}
+
+type WifiP2PDevice interface {
+	Device
+
+	// GetHwAddress gets the hardware address of the device.
+	GetHwAddress() (string, error)
+
+	// GetPeers gets the peers currently visible to the device.
+	GetPeers() ([]WifiP2PPeer, error)
+
+	// StartFind starts discovering peers. The discovery stops after timeout
+	// seconds, from 1 to 600, or after 30 seconds if timeout is 0.
+	StartFind(timeout int32) error
+
+	// StopFind stops a discovery started with StartFind.
+	StopFind() error
+
+	// SubscribePeers returns a channel receiving the peers that appear and
+	// disappear.
+	SubscribePeers() <-chan WifiP2PPeerEvent
+
+	// UnsubscribePeers stops the subscription and closes its channel.
+	UnsubscribePeers()
+}
+
+func NewWifiP2PDevice(objectPath dbus.ObjectPath) (WifiP2PDevice, error) {
+	var d wifiP2PDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type wifiP2PDevice struct {
+	device
+
+	peerSub  *signalSubscription
+	peerChan chan WifiP2PPeerEvent
+}
+
+func (d *wifiP2PDevice) GetHwAddress() (string, error) {
+	return d.getStringProperty(WifiP2PDevicePropertyHwAddress)
+}
+
+func (d *wifiP2PDevice) GetPeers() ([]WifiP2PPeer, error) {
+	paths, err := d.getSliceObjectProperty(WifiP2PDevicePropertyPeers)
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]WifiP2PPeer, len(paths))
+	for i, path := range paths {
+		peers[i], err = NewWifiP2PPeer(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return peers, nil
+}
+
+func (d *wifiP2PDevice) StartFind(timeout int32) error {
+	options := map[string]dbus.Variant{}
+	if timeout != 0 {
+		options["timeout"] = dbus.MakeVariant(timeout)
+	}
+	return d.obj.Call(WifiP2PDeviceStartFind, 0, options).Store()
+}
+
+func (d *wifiP2PDevice) StopFind() error {
+	return d.obj.Call(WifiP2PDeviceStopFind, 0).Store()
+}
+
+func (d *wifiP2PDevice) SubscribePeers() <-chan WifiP2PPeerEvent {
+	if d.peerChan != nil {
+		return d.peerChan
+	}
+
+	sub := d.subscribeSignals(WifiP2PDeviceInterface, WifiP2PDeviceSignalPeerAdded, WifiP2PDeviceSignalPeerRemoved)
+	peerChan := make(chan WifiP2PPeerEvent, 10)
+	d.peerSub, d.peerChan = sub, peerChan
+
+	go func() {
+		defer close(peerChan)
+		for {
+			sig, ok := sub.next()
+			if !ok {
+				return
+			}
+			if len(sig.Body) != 1 {
+				continue
+			}
+			path, ok := sig.Body[0].(dbus.ObjectPath)
+			if !ok {
+				continue
+			}
+			peer, err := NewWifiP2PPeer(path)
+			if err != nil {
+				continue
+			}
+			event := WifiP2PPeerEvent{
+				Removed: sig.Name == WifiP2PDeviceInterface+"."+WifiP2PDeviceSignalPeerRemoved,
+				Peer:    peer,
+			}
+			select {
+			case peerChan <- event:
+			case <-sub.closed():
+				return
+			}
+		}
+	}()
+
+	return peerChan
+}
+
+func (d *wifiP2PDevice) UnsubscribePeers() {
+	if d.peerSub == nil {
+		return
+	}
+	d.peerSub.close()
+	d.peerSub = nil
+	d.peerChan = nil
+}
+
+func (d *wifiP2PDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["HwAddress"], err = d.GetHwAddress(); err != nil {
+		return nil, err
+	}
+	if m["Peers"], err = d.GetPeers(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
@@ -0,0 +1,137 @@
+package gonetworkmanager
+
+import (
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	WifiP2PPeerInterface = NetworkManagerInterface + ".WifiP2PPeer"
+
+	WifiP2PPeerPropertyName         = WifiP2PPeerInterface + ".Name"
+	WifiP2PPeerPropertyFlags        = WifiP2PPeerInterface + ".Flags"
+	WifiP2PPeerPropertyManufacturer = WifiP2PPeerInterface + ".Manufacturer"
+	WifiP2PPeerPropertyModel        = WifiP2PPeerInterface + ".Model"
+	WifiP2PPeerPropertyModelNumber  = WifiP2PPeerInterface + ".ModelNumber"
+	WifiP2PPeerPropertySerial       = WifiP2PPeerInterface + ".Serial"
+	WifiP2PPeerPropertyHwAddress    = WifiP2PPeerInterface + ".HwAddress"
+	WifiP2PPeerPropertyStrength     = WifiP2PPeerInterface + ".Strength"
+	WifiP2PPeerPropertyLastSeen     = WifiP2PPeerInterface + ".LastSeen"
+)
+
+type WifiP2PPeer interface {
+	GetPath() dbus.ObjectPath
+
+	// GetName gets the device name of the peer.
+	GetName() (string, error)
+
+	// GetFlags gets the capabilities of the peer.
+	GetFlags() (Nm80211APFlags, error)
+
+	// GetManufacturer gets the manufacturer of the peer.
+	GetManufacturer() (string, error)
+
+	// GetModel gets the model of the peer.
+	GetModel() (string, error)
+
+	// GetModelNumber gets the model number of the peer.
+	GetModelNumber() (string, error)
+
+	// GetSerial gets the serial number of the peer.
+	GetSerial() (string, error)
+
+	// GetHwAddress gets the hardware address of the peer.
+	GetHwAddress() (string, error)
+
+	// GetStrength gets the current signal quality of the peer, in percent.
+	GetStrength() (uint8, error)
+
+	// GetLastSeen gets the CLOCK_BOOTTIME timestamp, in seconds, of when the
+	// peer was last seen, or -1 if it was never seen.
+	GetLastSeen() (int32, error)
+
+	MarshalJSON() ([]byte, error)
+}
+
+func NewWifiP2PPeer(objectPath dbus.ObjectPath) (WifiP2PPeer, error) {
+	var p wifiP2PPeer
+	return &p, p.init(NetworkManagerInterface, objectPath)
+}
+
+type wifiP2PPeer struct {
+	dbusBase
+}
+
+func (p *wifiP2PPeer) GetPath() dbus.ObjectPath {
+	return p.obj.Path()
+}
+
+func (p *wifiP2PPeer) GetName() (string, error) {
+	return p.getStringProperty(WifiP2PPeerPropertyName)
+}
+
+func (p *wifiP2PPeer) GetFlags() (Nm80211APFlags, error) {
+	v, err := p.getUint32Property(WifiP2PPeerPropertyFlags)
+	return Nm80211APFlags(v), err
+}
+
+func (p *wifiP2PPeer) GetManufacturer() (string, error) {
+	return p.getStringProperty(WifiP2PPeerPropertyManufacturer)
+}
+
+func (p *wifiP2PPeer) GetModel() (string, error) {
+	return p.getStringProperty(WifiP2PPeerPropertyModel)
+}
+
+func (p *wifiP2PPeer) GetModelNumber() (string, error) {
+	return p.getStringProperty(WifiP2PPeerPropertyModelNumber)
+}
+
+func (p *wifiP2PPeer) GetSerial() (string, error) {
+	return p.getStringProperty(WifiP2PPeerPropertySerial)
+}
+
+func (p *wifiP2PPeer) GetHwAddress() (string, error) {
+	return p.getStringProperty(WifiP2PPeerPropertyHwAddress)
+}
+
+func (p *wifiP2PPeer) GetStrength() (uint8, error) {
+	return p.getUint8Property(WifiP2PPeerPropertyStrength)
+}
+
+func (p *wifiP2PPeer) GetLastSeen() (int32, error) {
+	return p.getInt32Property(WifiP2PPeerPropertyLastSeen)
+}
+
+func (p *wifiP2PPeer) MarshalJSON() ([]byte, error) {
+	m := make(map[string]interface{})
+	var err error
+	if m["Name"], err = p.GetName(); err != nil {
+		return nil, err
+	}
+	if m["Manufacturer"], err = p.GetManufacturer(); err != nil {
+		return nil, err
+	}
+	if m["Model"], err = p.GetModel(); err != nil {
+		return nil, err
+	}
+	if m["HwAddress"], err = p.GetHwAddress(); err != nil {
+		return nil, err
+	}
+	if m["Strength"], err = p.GetStrength(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
+
+// NewWifiP2PConnectionSettings returns a profile connecting to the peer with
+// the hardware address peerHwAddress, as returned by WifiP2PPeer.GetHwAddress.
+// Activate it on the WifiP2PDevice with NetworkManager.ActivateConnection.
+func NewWifiP2PConnectionSettings(id, peerHwAddress string) (ConnectionSettings, error) {
+	settings := newConnectionSettings(id, SettingWifiP2P)
+	settings[SettingWifiP2P] = map[string]interface{}{
+		"peer": peerHwAddress,
+	}
+	return checkedSettings(settings)
+}
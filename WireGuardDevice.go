@@ -0,0 +1,73 @@
+package gonetworkmanager
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/godbus/dbus"
+)
+
+const (
+	WireGuardDeviceInterface = DeviceInterface + ".WireGuard"
+
+	WireGuardDevicePropertyPublicKey  = WireGuardDeviceInterface + ".PublicKey"
+	WireGuardDevicePropertyListenPort = WireGuardDeviceInterface + ".ListenPort"
+	WireGuardDevicePropertyFwMark     = WireGuardDeviceInterface + ".FwMark"
+)
+
+type WireGuardDevice interface {
+	Device
+
+	// GetPublicKey gets the base64 encoded public key of the interface.
+	GetPublicKey() (string, error)
+
+	// GetListenPort gets the UDP port the interface listens on, 0 if not
+	// listening.
+	GetListenPort() (uint16, error)
+
+	// GetFwMark gets the firewall mark of the packets sent by the interface,
+	// 0 if disabled.
+	GetFwMark() (uint32, error)
+}
+
+func NewWireGuardDevice(objectPath dbus.ObjectPath) (WireGuardDevice, error) {
+	var d wireGuardDevice
+	return &d, d.init(NetworkManagerInterface, objectPath)
+}
+
+type wireGuardDevice struct {
+	device
+}
+
+func (d *wireGuardDevice) GetPublicKey() (string, error) {
+	key, err := d.getSliceByteProperty(WireGuardDevicePropertyPublicKey)
+	if err != nil || len(key) == 0 {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+func (d *wireGuardDevice) GetListenPort() (uint16, error) {
+	return d.getUint16Property(WireGuardDevicePropertyListenPort)
+}
+
+func (d *wireGuardDevice) GetFwMark() (uint32, error) {
+	return d.getUint32Property(WireGuardDevicePropertyFwMark)
+}
+
+func (d *wireGuardDevice) MarshalJSON() ([]byte, error) {
+	m, err := d.device.marshalMap()
+	if err != nil {
+		return nil, err
+	}
+	if m["PublicKey"], err = d.GetPublicKey(); err != nil {
+		return nil, err
+	}
+	if m["ListenPort"], err = d.GetListenPort(); err != nil {
+		return nil, err
+	}
+	if m["FwMark"], err = d.GetFwMark(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(m)
+}
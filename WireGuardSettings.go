@@ -0,0 +1,180 @@
+package gonetworkmanager
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// WireGuardPeer is an element of the wireguard.peers setting.
+type WireGuardPeer struct {
+	// PublicKey is the base64 encoded public key of the peer.
+	PublicKey string
+
+	// PresharedKey is an optional base64 encoded preshared key. Like the
+	// private key of the interface it is a secret, not returned by
+	// Connection.GetSettings.
+	PresharedKey string
+
+	// Endpoint is the "host:port" of the peer, if known.
+	Endpoint string
+
+	// AllowedIPs are the networks, in CIDR notation, routed to the peer.
+	AllowedIPs []string
+
+	// PersistentKeepalive is the keepalive interval in seconds, 0 to
+	// disable it.
+	PersistentKeepalive uint32
+}
+
+// settingsMap returns the peer as an element of wireguard.peers, with the
+// preshared key flagged by secretFlags.
+func (p WireGuardPeer) settingsMap(secretFlags uint32) map[string]interface{} {
+	m := map[string]interface{}{
+		"public-key": p.PublicKey,
+	}
+	if p.PresharedKey != "" {
+		m["preshared-key"] = p.PresharedKey
+		m["preshared-key-flags"] = secretFlags
+	}
+	if p.Endpoint != "" {
+		m["endpoint"] = p.Endpoint
+	}
+	if len(p.AllowedIPs) > 0 {
+		m["allowed-ips"] = p.AllowedIPs
+	}
+	if p.PersistentKeepalive != 0 {
+		m["persistent-keepalive"] = p.PersistentKeepalive
+	}
+	return m
+}
+
+// GetWireGuardPeers returns the peers of the wireguard setting of settings.
+func GetWireGuardPeers(settings ConnectionSettings) ([]WireGuardPeer, error) {
+	raw, ok := settings[SettingWireGuard]["peers"]
+	if !ok {
+		return nil, nil
+	}
+	maps, err := toMaps(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s.peers: %v", SettingWireGuard, err)
+	}
+
+	peers := make([]WireGuardPeer, len(maps))
+	for i, m := range maps {
+		peers[i].PublicKey, _ = m["public-key"].(string)
+		peers[i].PresharedKey, _ = m["preshared-key"].(string)
+		peers[i].Endpoint, _ = m["endpoint"].(string)
+		peers[i].AllowedIPs, _ = m["allowed-ips"].([]string)
+		if v, ok := m["persistent-keepalive"]; ok {
+			n, err := kindUint32.convert(v)
+			if err != nil {
+				return nil, fmt.Errorf("%s.peers[%d].persistent-keepalive: %v", SettingWireGuard, i, err)
+			}
+			peers[i].PersistentKeepalive = n.(uint32)
+		}
+	}
+	return peers, nil
+}
+
+// SetWireGuardPeers replaces the peers of the wireguard setting of settings.
+// Preshared keys get the same secret flags as the private key.
+func SetWireGuardPeers(settings ConnectionSettings, peers []WireGuardPeer) {
+	wg := settings[SettingWireGuard]
+	if wg == nil {
+		wg = make(map[string]interface{})
+		settings[SettingWireGuard] = wg
+	}
+	flags, _ := wg["private-key-flags"].(uint32)
+
+	maps := make([]map[string]interface{}, len(peers))
+	for i, p := range peers {
+		maps[i] = p.settingsMap(flags)
+	}
+	wg["peers"] = maps
+}
+
+// WireGuardOptions are the options of a WireGuard profile. WireGuard is not a
+// VPN plugin but a device type of its own, configured through the wireguard
+// setting.
+type WireGuardOptions struct {
+	// InterfaceName is the name of the WireGuard interface to create.
+	InterfaceName string
+
+	// PrivateKey is the base64 encoded private key of the interface.
+	PrivateKey string
+
+	// AgentOwned flags the private and preshared keys as owned by a secret
+	// agent, such as a SecretProviderAgent, instead of being stored by
+	// NetworkManager.
+	AgentOwned bool
+
+	ListenPort uint32
+	FwMark     uint32
+
+	// Addresses are the addresses of the interface in CIDR notation. IPv4
+	// and IPv6 addresses may be mixed.
+	Addresses []string
+
+	Peers []WireGuardPeer
+}
+
+// NewWireGuardConnectionSettings returns a WireGuard profile. The private key
+// and the preshared keys of the peers are set as secrets of the profile.
+func NewWireGuardConnectionSettings(id string, opts WireGuardOptions) (ConnectionSettings, error) {
+	if opts.InterfaceName == "" {
+		return nil, fmt.Errorf("wireguard: interface name is required")
+	}
+
+	settings := newConnectionSettings(id, SettingWireGuard)
+	settings[SettingConnection]["interface-name"] = opts.InterfaceName
+	settings[SettingIP4Config]["method"] = "disabled"
+	settings[SettingIP6Config]["method"] = "ignore"
+
+	var ip4, ip6 []string
+	for _, addr := range opts.Addresses {
+		entry, err := parseIPEntry(addr)
+		if err != nil {
+			return nil, fmt.Errorf("wireguard: %v", err)
+		}
+		if entry.IP.To4() != nil {
+			ip4 = append(ip4, addr)
+		} else {
+			ip6 = append(ip6, addr)
+		}
+	}
+	if len(ip4) > 0 {
+		settings[SettingIP4Config]["method"] = "manual"
+		settings[SettingIP4Config]["address-data"] = ip4
+	}
+	if len(ip6) > 0 {
+		settings[SettingIP6Config]["method"] = "manual"
+		settings[SettingIP6Config]["address-data"] = ip6
+	}
+
+	flags := uint32(0)
+	if opts.AgentOwned {
+		flags = 1
+	}
+	wg := map[string]interface{}{
+		"private-key-flags": flags,
+	}
+	if opts.PrivateKey != "" {
+		wg["private-key"] = opts.PrivateKey
+	}
+	if opts.ListenPort != 0 {
+		wg["listen-port"] = opts.ListenPort
+	}
+	if opts.FwMark != 0 {
+		wg["fwmark"] = opts.FwMark
+	}
+	settings[SettingWireGuard] = wg
+	SetWireGuardPeers(settings, opts.Peers)
+
+	return checkedSettings(settings)
+}
+
+// validWireGuardKey reports whether key is a base64 encoded 32 byte key.
+func validWireGuardKey(key string) bool {
+	b, err := base64.StdEncoding.DecodeString(key)
+	return err == nil && len(b) == 32
+}
@@ -11,6 +11,9 @@ const (
 
 	WirelessDeviceGetAccessPoints = WirelessDeviceInterface + ".GetAccessPoints"
 	WirelessDeviceRequestScan     = WirelessDeviceInterface + ".RequestScan"
+
+	WirelessDevicePropertyMode                 = WirelessDeviceInterface + ".Mode"
+	WirelessDevicePropertyWirelessCapabilities = WirelessDeviceInterface + ".WirelessCapabilities"
 )
 
 type WirelessDevice interface {
@@ -23,6 +26,17 @@ type WirelessDevice interface {
 	GetAccessPoints() ([]AccessPoint, error)
 
 	RequestScan() error
+
+	// GetMode gets the operating mode of the device, Nm80211ModeAp while it
+	// runs a hotspot.
+	GetMode() (Nm80211Mode, error)
+
+	// GetWirelessCapabilities gets the capabilities of the device.
+	GetWirelessCapabilities() (NmWifiDeviceCapabilities, error)
+
+	// SupportsAP reports whether the device can run an access point, see
+	// StartHotspot.
+	SupportsAP() (bool, error)
 }
 
 func NewWirelessDevice(objectPath dbus.ObjectPath) (WirelessDevice, error) {
@@ -58,6 +72,21 @@ func (d *wirelessDevice) RequestScan() error {
 	return d.obj.Call(WirelessDeviceRequestScan, 0, options).Store()
 }
 
+func (d *wirelessDevice) GetMode() (Nm80211Mode, error) {
+	v, err := d.getUint32Property(WirelessDevicePropertyMode)
+	return Nm80211Mode(v), err
+}
+
+func (d *wirelessDevice) GetWirelessCapabilities() (NmWifiDeviceCapabilities, error) {
+	v, err := d.getUint32Property(WirelessDevicePropertyWirelessCapabilities)
+	return NmWifiDeviceCapabilities(v), err
+}
+
+func (d *wirelessDevice) SupportsAP() (bool, error) {
+	caps, err := d.GetWirelessCapabilities()
+	return caps&NmWifiDeviceCapabilitiesAp != 0, err
+}
+
 func (d *wirelessDevice) MarshalJSON() ([]byte, error) {
 	m, err := d.device.marshalMap()
 	if err != nil {
@@ -48,22 +48,41 @@ const (
 type NmDeviceType uint32
 
 const (
-	NmDeviceTypeUnknown    NmDeviceType = 0
-	NmDeviceTypeEthernet   NmDeviceType = 1
-	NmDeviceTypeWifi       NmDeviceType = 2
-	NmDeviceTypeUnused1    NmDeviceType = 3
-	NmDeviceTypeUnused2    NmDeviceType = 4
-	NmDeviceTypeBt         NmDeviceType = 5
-	NmDeviceTypeOlpcMesh   NmDeviceType = 6
-	NmDeviceTypeWimax      NmDeviceType = 7
-	NmDeviceTypeModem      NmDeviceType = 8
-	NmDeviceTypeInfiniband NmDeviceType = 9
-	NmDeviceTypeBond       NmDeviceType = 10
-	NmDeviceTypeVlan       NmDeviceType = 11
-	NmDeviceTypeAdsl       NmDeviceType = 12
-	NmDeviceTypeBridge     NmDeviceType = 13
-	NmDeviceTypeGeneric    NmDeviceType = 14
-	NmDeviceTypeTeam       NmDeviceType = 15
+	NmDeviceTypeUnknown      NmDeviceType = 0
+	NmDeviceTypeEthernet     NmDeviceType = 1
+	NmDeviceTypeWifi         NmDeviceType = 2
+	NmDeviceTypeUnused1      NmDeviceType = 3
+	NmDeviceTypeUnused2      NmDeviceType = 4
+	NmDeviceTypeBt           NmDeviceType = 5
+	NmDeviceTypeOlpcMesh     NmDeviceType = 6
+	NmDeviceTypeWimax        NmDeviceType = 7
+	NmDeviceTypeModem        NmDeviceType = 8
+	NmDeviceTypeInfiniband   NmDeviceType = 9
+	NmDeviceTypeBond         NmDeviceType = 10
+	NmDeviceTypeVlan         NmDeviceType = 11
+	NmDeviceTypeAdsl         NmDeviceType = 12
+	NmDeviceTypeBridge       NmDeviceType = 13
+	NmDeviceTypeGeneric      NmDeviceType = 14
+	NmDeviceTypeTeam         NmDeviceType = 15
+	NmDeviceTypeTun          NmDeviceType = 16
+	NmDeviceTypeIpTunnel     NmDeviceType = 17
+	NmDeviceTypeMacvlan      NmDeviceType = 18
+	NmDeviceTypeVxlan        NmDeviceType = 19
+	NmDeviceTypeVeth         NmDeviceType = 20
+	NmDeviceTypeMacsec       NmDeviceType = 21
+	NmDeviceTypeDummy        NmDeviceType = 22
+	NmDeviceTypePpp          NmDeviceType = 23
+	NmDeviceTypeOvsInterface NmDeviceType = 24
+	NmDeviceTypeOvsPort      NmDeviceType = 25
+	NmDeviceTypeOvsBridge    NmDeviceType = 26
+	NmDeviceTypeWpan         NmDeviceType = 27
+	NmDeviceType6lowpan      NmDeviceType = 28
+	NmDeviceTypeWireguard    NmDeviceType = 29
+	NmDeviceTypeWifiP2p      NmDeviceType = 30
+	NmDeviceTypeVrf          NmDeviceType = 31
+	NmDeviceTypeLoopback     NmDeviceType = 32
+	NmDeviceTypeHsr          NmDeviceType = 33
+	NmDeviceTypeIpvlan       NmDeviceType = 34
 )
 
 //go:generate stringer -type=Nm80211APFlags
@@ -100,3 +119,155 @@ const (
 	Nm80211ModeInfra   Nm80211Mode = 2
 	Nm80211ModeAp      Nm80211Mode = 3
 )
+
+//go:generate stringer -type=NmSecretAgentGetSecretsFlags
+type NmSecretAgentGetSecretsFlags uint32
+
+const (
+	NmSecretAgentGetSecretsFlagNone             NmSecretAgentGetSecretsFlags = 0x0
+	NmSecretAgentGetSecretsFlagAllowInteraction NmSecretAgentGetSecretsFlags = 0x1
+	NmSecretAgentGetSecretsFlagRequestNew       NmSecretAgentGetSecretsFlags = 0x2
+	NmSecretAgentGetSecretsFlagUserRequested    NmSecretAgentGetSecretsFlags = 0x4
+	NmSecretAgentGetSecretsFlagWpsPbcActive     NmSecretAgentGetSecretsFlags = 0x8
+	NmSecretAgentGetSecretsFlagOnlySystem       NmSecretAgentGetSecretsFlags = 0x80000000
+	NmSecretAgentGetSecretsFlagNoErrors         NmSecretAgentGetSecretsFlags = 0x40000000
+)
+
+//go:generate stringer -type=NmSecretAgentCapabilities
+type NmSecretAgentCapabilities uint32
+
+const (
+	NmSecretAgentCapabilityNone     NmSecretAgentCapabilities = 0x0
+	NmSecretAgentCapabilityVpnHints NmSecretAgentCapabilities = 0x1
+)
+
+//go:generate stringer -type=NmVpnConnectionState
+type NmVpnConnectionState uint32
+
+const (
+	NmVpnConnectionStateUnknown      NmVpnConnectionState = 0
+	NmVpnConnectionStatePrepare      NmVpnConnectionState = 1
+	NmVpnConnectionStateNeedAuth     NmVpnConnectionState = 2
+	NmVpnConnectionStateConnect      NmVpnConnectionState = 3
+	NmVpnConnectionStateIpConfigGet  NmVpnConnectionState = 4
+	NmVpnConnectionStateActivated    NmVpnConnectionState = 5
+	NmVpnConnectionStateFailed       NmVpnConnectionState = 6
+	NmVpnConnectionStateDisconnected NmVpnConnectionState = 7
+)
+
+//go:generate stringer -type=NmVpnConnectionStateReason
+type NmVpnConnectionStateReason uint32
+
+const (
+	NmVpnConnectionStateReasonUnknown             NmVpnConnectionStateReason = 0
+	NmVpnConnectionStateReasonNone                NmVpnConnectionStateReason = 1
+	NmVpnConnectionStateReasonUserDisconnected    NmVpnConnectionStateReason = 2
+	NmVpnConnectionStateReasonDeviceDisconnected  NmVpnConnectionStateReason = 3
+	NmVpnConnectionStateReasonServiceStopped      NmVpnConnectionStateReason = 4
+	NmVpnConnectionStateReasonIpConfigInvalid     NmVpnConnectionStateReason = 5
+	NmVpnConnectionStateReasonConnectTimeout      NmVpnConnectionStateReason = 6
+	NmVpnConnectionStateReasonServiceStartTimeout NmVpnConnectionStateReason = 7
+	NmVpnConnectionStateReasonServiceStartFailed  NmVpnConnectionStateReason = 8
+	NmVpnConnectionStateReasonNoSecrets           NmVpnConnectionStateReason = 9
+	NmVpnConnectionStateReasonLoginFailed         NmVpnConnectionStateReason = 10
+	NmVpnConnectionStateReasonConnectionRemoved   NmVpnConnectionStateReason = 11
+)
+
+//go:generate stringer -type=NmDeviceModemCapabilities
+type NmDeviceModemCapabilities uint32
+
+const (
+	NmDeviceModemCapabilitiesNone     NmDeviceModemCapabilities = 0x0
+	NmDeviceModemCapabilitiesPots     NmDeviceModemCapabilities = 0x1
+	NmDeviceModemCapabilitiesCdmaEvdo NmDeviceModemCapabilities = 0x2
+	NmDeviceModemCapabilitiesGsmUmts  NmDeviceModemCapabilities = 0x4
+	NmDeviceModemCapabilitiesLte      NmDeviceModemCapabilities = 0x8
+	NmDeviceModemCapabilities5gnr     NmDeviceModemCapabilities = 0x40
+)
+
+//go:generate stringer -type=NmBluetoothCapabilities
+type NmBluetoothCapabilities uint32
+
+const (
+	NmBluetoothCapabilitiesNone NmBluetoothCapabilities = 0x0
+	NmBluetoothCapabilitiesDun  NmBluetoothCapabilities = 0x1
+	NmBluetoothCapabilitiesNap  NmBluetoothCapabilities = 0x2
+)
+
+//go:generate stringer -type=NmIPTunnelMode
+type NmIPTunnelMode uint32
+
+const (
+	NmIPTunnelModeUnknown   NmIPTunnelMode = 0
+	NmIPTunnelModeIpip      NmIPTunnelMode = 1
+	NmIPTunnelModeGre       NmIPTunnelMode = 2
+	NmIPTunnelModeSit       NmIPTunnelMode = 3
+	NmIPTunnelModeIpip6     NmIPTunnelMode = 4
+	NmIPTunnelModeIp6ip6    NmIPTunnelMode = 5
+	NmIPTunnelModeIpgre     NmIPTunnelMode = 6
+	NmIPTunnelModeIp6gre    NmIPTunnelMode = 7
+	NmIPTunnelModeVti       NmIPTunnelMode = 8
+	NmIPTunnelModeVti6      NmIPTunnelMode = 9
+	NmIPTunnelModeGretap    NmIPTunnelMode = 10
+	NmIPTunnelModeIp6gretap NmIPTunnelMode = 11
+)
+
+//go:generate stringer -type=NmWifiDeviceCapabilities
+type NmWifiDeviceCapabilities uint32
+
+const (
+	NmWifiDeviceCapabilitiesNone         NmWifiDeviceCapabilities = 0x0
+	NmWifiDeviceCapabilitiesCipherWep40  NmWifiDeviceCapabilities = 0x1
+	NmWifiDeviceCapabilitiesCipherWep104 NmWifiDeviceCapabilities = 0x2
+	NmWifiDeviceCapabilitiesCipherTkip   NmWifiDeviceCapabilities = 0x4
+	NmWifiDeviceCapabilitiesCipherCcmp   NmWifiDeviceCapabilities = 0x8
+	NmWifiDeviceCapabilitiesWpa          NmWifiDeviceCapabilities = 0x10
+	NmWifiDeviceCapabilitiesRsn          NmWifiDeviceCapabilities = 0x20
+	NmWifiDeviceCapabilitiesAp           NmWifiDeviceCapabilities = 0x40
+	NmWifiDeviceCapabilitiesAdhoc        NmWifiDeviceCapabilities = 0x80
+	NmWifiDeviceCapabilitiesFreqValid    NmWifiDeviceCapabilities = 0x100
+	NmWifiDeviceCapabilitiesFreq2Ghz     NmWifiDeviceCapabilities = 0x200
+	NmWifiDeviceCapabilitiesFreq5Ghz     NmWifiDeviceCapabilities = 0x400
+	NmWifiDeviceCapabilitiesMesh         NmWifiDeviceCapabilities = 0x1000
+	NmWifiDeviceCapabilitiesIbssRsn      NmWifiDeviceCapabilities = 0x2000
+)
+
+//go:generate stringer -type=NmMetered
+type NmMetered uint32
+
+const (
+	NmMeteredUnknown  NmMetered = 0
+	NmMeteredYes      NmMetered = 1
+	NmMeteredNo       NmMetered = 2
+	NmMeteredGuessYes NmMetered = 3
+	NmMeteredGuessNo  NmMetered = 4
+)
+
+//go:generate stringer -type=NmCapability
+type NmCapability uint32
+
+const (
+	NmCapabilityTeam NmCapability = 1
+	NmCapabilityOvs  NmCapability = 2
+)
+
+//go:generate stringer -type=NmCheckpointCreateFlags
+type NmCheckpointCreateFlags uint32
+
+const (
+	NmCheckpointCreateFlagNone                 NmCheckpointCreateFlags = 0x0
+	NmCheckpointCreateFlagDestroyAll           NmCheckpointCreateFlags = 0x1
+	NmCheckpointCreateFlagDeleteNewConnections NmCheckpointCreateFlags = 0x2
+	NmCheckpointCreateFlagDisconnectNewDevices NmCheckpointCreateFlags = 0x4
+	NmCheckpointCreateFlagAllowOverlapping     NmCheckpointCreateFlags = 0x8
+)
+
+//go:generate stringer -type=NmRollbackResult
+type NmRollbackResult uint32
+
+const (
+	NmRollbackResultOk                 NmRollbackResult = 0
+	NmRollbackResultErrNoDevice        NmRollbackResult = 1
+	NmRollbackResultErrDeviceUnmanaged NmRollbackResult = 2
+	NmRollbackResultErrFailed          NmRollbackResult = 3
+)
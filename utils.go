@@ -1,15 +1,25 @@
 package gonetworkmanager
 
 import (
+	"crypto/rand"
 	"encoding/binary"
 	"fmt"
 	"net"
+	"strings"
 
 	"github.com/godbus/dbus"
 )
 
 const (
-	dbusMethodAddMatch = "org.freedesktop.DBus.AddMatch"
+	dbusInterface              = "org.freedesktop.DBus"
+	dbusObjectPath             = "/org/freedesktop/DBus"
+	dbusMethodAddMatch         = dbusInterface + ".AddMatch"
+	dbusMethodRemoveMatch      = dbusInterface + ".RemoveMatch"
+	dbusSignalNameOwnerChanged = "NameOwnerChanged"
+
+	dbusPropertiesInterface     = "org.freedesktop.DBus.Properties"
+	dbusMethodPropertiesSet     = dbusPropertiesInterface + ".Set"
+	dbusSignalPropertiesChanged = "PropertiesChanged"
 )
 
 type dbusBase struct {
@@ -38,10 +48,90 @@ func (d *dbusBase) call2(value1 interface{}, value2 interface{}, method string,
 	return d.obj.Call(method, 0, args...).Store(value1, value2)
 }
 
-func (d *dbusBase) subscribe(iface, member string) {
-	rule := fmt.Sprintf("type='signal',interface='%s',path='%s',member='%s'",
-		iface, d.obj.Path(), NetworkManagerInterface)
+// signalSubscription receives the signals of one object on a channel of its
+// own, for the Subscribe* methods that convert them into typed events.
+type signalSubscription struct {
+	conn    *dbus.Conn
+	path    dbus.ObjectPath
+	names   []string
+	rules   []string
+	sigChan chan *dbus.Signal
+	done    chan struct{}
+}
+
+// subscribeSignals subscribes to the signals iface.member of the object for
+// each of members.
+func (d *dbusBase) subscribeSignals(iface string, members ...string) *signalSubscription {
+	s := &signalSubscription{
+		conn:    d.conn,
+		path:    d.obj.Path(),
+		sigChan: make(chan *dbus.Signal, 10),
+		done:    make(chan struct{}),
+	}
+	for _, member := range members {
+		rule := fmt.Sprintf("type='signal',interface='%s',path='%s',member='%s'",
+			iface, s.path, member)
+		d.conn.BusObject().Call(dbusMethodAddMatch, 0, rule)
+		s.rules = append(s.rules, rule)
+		s.names = append(s.names, iface+"."+member)
+	}
+	d.conn.Signal(s.sigChan)
+	return s
+}
+
+// subscribeNameOwnerChanged subscribes to the NameOwnerChanged signals of the
+// bus for name. The signal body is the name, its old owner and its new owner,
+// which is empty when the name was released.
+func (d *dbusBase) subscribeNameOwnerChanged(name string) *signalSubscription {
+	s := &signalSubscription{
+		conn:    d.conn,
+		path:    dbusObjectPath,
+		sigChan: make(chan *dbus.Signal, 10),
+		done:    make(chan struct{}),
+	}
+	rule := fmt.Sprintf("type='signal',sender='%s',interface='%s',path='%s',member='%s',arg0='%s'",
+		dbusInterface, dbusInterface, dbusObjectPath, dbusSignalNameOwnerChanged, name)
 	d.conn.BusObject().Call(dbusMethodAddMatch, 0, rule)
+	s.rules = append(s.rules, rule)
+	s.names = append(s.names, dbusInterface+"."+dbusSignalNameOwnerChanged)
+	d.conn.Signal(s.sigChan)
+	return s
+}
+
+// next returns the next subscribed signal. It returns false once the
+// subscription is closed or the bus connection is terminated.
+func (s *signalSubscription) next() (*dbus.Signal, bool) {
+	for {
+		select {
+		case sig, ok := <-s.sigChan:
+			if !ok {
+				return nil, false
+			}
+			for _, name := range s.names {
+				if sig.Path == s.path && sig.Name == name {
+					return sig, true
+				}
+			}
+		case <-s.done:
+			return nil, false
+		}
+	}
+}
+
+// closed returns a channel that is closed with the subscription, to abort
+// sending an event nobody receives anymore.
+func (s *signalSubscription) closed() <-chan struct{} {
+	return s.done
+}
+
+// close removes the subscription. The signal channel itself is not closed,
+// as godbus may still deliver to it after RemoveSignal.
+func (s *signalSubscription) close() {
+	for _, rule := range s.rules {
+		s.conn.BusObject().Call(dbusMethodRemoveMatch, 0, rule)
+	}
+	s.conn.RemoveSignal(s.sigChan)
+	close(s.done)
 }
 
 func (d *dbusBase) subscribeNamespace(namespace string) {
@@ -57,6 +147,27 @@ func (d *dbusBase) getProperty(iface string) (interface{}, error) {
 	return variant.Value(), nil
 }
 
+// changedProperties returns the properties of iface changed according to a
+// org.freedesktop.DBus.Properties.PropertiesChanged signal, keyed by their
+// short name.
+func changedProperties(sig *dbus.Signal, iface string) (map[string]dbus.Variant, bool) {
+	if len(sig.Body) < 2 {
+		return nil, false
+	}
+	if name, _ := sig.Body[0].(string); name != iface {
+		return nil, false
+	}
+	changed, ok := sig.Body[1].(map[string]dbus.Variant)
+	return changed, ok
+}
+
+// setProperty sets a writable property, given as "interface.Property" like
+// for getProperty.
+func (d *dbusBase) setProperty(iface string, value interface{}) error {
+	i := strings.LastIndex(iface, ".")
+	return d.obj.Call(dbusMethodPropertiesSet, 0, iface[:i], iface[i+1:], dbus.MakeVariant(value)).Store()
+}
+
 func (d *dbusBase) getObjectProperty(iface string) (dbus.ObjectPath, error) {
 	value, err := d.getProperty(iface)
 	if err != nil {
@@ -97,6 +208,22 @@ func (d *dbusBase) getMapStringVariantProperty(iface string) (map[string]dbus.Va
 	return value.(map[string]dbus.Variant), nil
 }
 
+func (d *dbusBase) getSliceMapStringVariantProperty(iface string) ([]map[string]dbus.Variant, error) {
+	value, err := d.getProperty(iface)
+	if err != nil {
+		return nil, makeErrVariantType(iface)
+	}
+	return value.([]map[string]dbus.Variant), nil
+}
+
+func (d *dbusBase) getBoolProperty(iface string) (bool, error) {
+	value, err := d.getProperty(iface)
+	if err != nil {
+		return false, makeErrVariantType(iface)
+	}
+	return value.(bool), nil
+}
+
 func (d *dbusBase) getUint8Property(iface string) (uint8, error) {
 	value, err := d.getProperty(iface)
 	if err != nil {
@@ -105,6 +232,14 @@ func (d *dbusBase) getUint8Property(iface string) (uint8, error) {
 	return value.(uint8), nil
 }
 
+func (d *dbusBase) getUint16Property(iface string) (uint16, error) {
+	value, err := d.getProperty(iface)
+	if err != nil {
+		return 0, makeErrVariantType(iface)
+	}
+	return value.(uint16), nil
+}
+
 func (d *dbusBase) getUint32Property(iface string) (uint32, error) {
 	value, err := d.getProperty(iface)
 	if err != nil {
@@ -113,6 +248,30 @@ func (d *dbusBase) getUint32Property(iface string) (uint32, error) {
 	return value.(uint32), nil
 }
 
+func (d *dbusBase) getInt32Property(iface string) (int32, error) {
+	value, err := d.getProperty(iface)
+	if err != nil {
+		return 0, makeErrVariantType(iface)
+	}
+	return value.(int32), nil
+}
+
+func (d *dbusBase) getInt64Property(iface string) (int64, error) {
+	value, err := d.getProperty(iface)
+	if err != nil {
+		return 0, makeErrVariantType(iface)
+	}
+	return value.(int64), nil
+}
+
+func (d *dbusBase) getUint64Property(iface string) (uint64, error) {
+	value, err := d.getProperty(iface)
+	if err != nil {
+		return 0, makeErrVariantType(iface)
+	}
+	return value.(uint64), nil
+}
+
 func (d *dbusBase) getSliceUint32Property(iface string) ([]uint32, error) {
 	value, err := d.getProperty(iface)
 	if err != nil {
@@ -146,3 +305,30 @@ func ip4ToString(ip uint32) string {
 	binary.LittleEndian.PutUint32(bs, ip)
 	return net.IP(bs).String()
 }
+
+// newUUID returns a random (version 4) UUID as used for connection.uuid.
+func newUUID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func ip4FromIP(ip net.IP) (uint32, bool) {
+	ip4 := ip.To4()
+	if ip4 == nil {
+		return 0, false
+	}
+	return binary.LittleEndian.Uint32(ip4), true
+}
+
+func ip4FromString(s string) (uint32, error) {
+	ip, ok := ip4FromIP(net.ParseIP(s))
+	if !ok {
+		return 0, fmt.Errorf("invalid IPv4 address '%s'", s)
+	}
+	return ip, nil
+}